@@ -1,3 +1,9 @@
 data "migadu_aliases" "example" {
   domain_name = "example.com"
 }
+
+# Only the internal aliases of the domain
+data "migadu_aliases" "internal" {
+  domain_name = "example.com"
+  is_internal = true
+}
@@ -0,0 +1,34 @@
+variable "password_rotation" {
+  description = "Increase to rotate the mailbox password and store the new one"
+  type        = number
+  default     = 1
+}
+
+# Generate a password for a mailbox and store it in a secrets manager without
+# the value ever being written to Terraform state. Both write-only arguments
+# share the rotation version, so the mailbox and the secret only change
+# together, when the version is increased.
+ephemeral "migadu_mailbox_password" "example" {
+  domain_name = "example.com"
+  local_part  = "hello"
+  length      = 32
+}
+
+resource "migadu_mailbox" "example" {
+  domain_name         = "example.com"
+  local_part          = "hello"
+  name                = "Hello"
+  password_method     = "password"
+  password_wo         = ephemeral.migadu_mailbox_password.example.password
+  password_wo_version = var.password_rotation
+}
+
+resource "aws_secretsmanager_secret" "mailbox" {
+  name = "mail/hello@example.com"
+}
+
+resource "aws_secretsmanager_secret_version" "mailbox" {
+  secret_id                = aws_secretsmanager_secret.mailbox.id
+  secret_string_wo         = ephemeral.migadu_mailbox_password.example.password
+  secret_string_wo_version = var.password_rotation
+}
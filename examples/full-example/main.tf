@@ -106,7 +106,7 @@ resource "migadu_alias" "campaign" {
   local_part   = "spring2026"
   destinations = [migadu_mailbox.sales.address]
 
-  expireable         = true
+  expirable         = true
   expires_on         = "2026-06-30"
   remove_upon_expiry = true
 }
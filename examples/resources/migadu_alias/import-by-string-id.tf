@@ -0,0 +1,4 @@
+import {
+  to = migadu_alias.example
+  id = "example.com/info"
+}
@@ -0,0 +1,3 @@
+# The import ID is domain_name/local_part or the email address.
+terraform import migadu_alias.example example.com/info
+terraform import migadu_alias.example info@example.com
@@ -10,8 +10,21 @@ resource "migadu_alias" "temporary" {
   local_part   = "temp"
   destinations = ["admin@example.com"]
 
-  expireable         = true
+  expirable         = true
   expires_on         = "2026-12-31"
   remove_upon_expiry = true
 }
 
+# Example internal distribution list, which rejects mail from outside senders
+resource "migadu_alias" "all_staff" {
+  domain_name  = "example.com"
+  local_part   = "all-staff"
+  destinations = ["alice@example.com", "bob@example.com"]
+  is_internal  = true
+}
+
+# Example addressed by the full email address
+resource "migadu_alias" "sales" {
+  address      = "sales@example.com"
+  destinations = ["hello@example.com"]
+}
@@ -0,0 +1,6 @@
+import {
+  to = migadu_domain.example
+  identity = {
+    name = "example.com"
+  }
+}
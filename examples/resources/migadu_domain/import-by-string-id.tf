@@ -0,0 +1,4 @@
+import {
+  to = migadu_domain.example
+  id = "example.com"
+}
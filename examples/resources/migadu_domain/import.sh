@@ -0,0 +1,2 @@
+# The import ID is name.
+terraform import migadu_domain.example example.com
@@ -12,3 +12,11 @@ resource "migadu_domain" "custom" {
 
   catchall_destinations = ["admin@custom.example.com"]
 }
+
+# Internationalized domain names can be written in Unicode. The punycode
+# form is available as name_ascii, for example for DNS records.
+resource "migadu_domain" "idn" {
+  name = "bücher.example"
+
+  catchall_destinations = ["info@bücher.example"]
+}
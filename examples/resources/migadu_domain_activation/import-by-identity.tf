@@ -0,0 +1,6 @@
+import {
+  to = migadu_domain_activation.example
+  identity = {
+    domain_name = "example.com"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = migadu_domain_activation.example
+  id = "example.com"
+}
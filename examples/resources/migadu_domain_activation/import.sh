@@ -0,0 +1,2 @@
+# The import ID is domain_name.
+terraform import migadu_domain_activation.example example.com
@@ -10,3 +10,13 @@ resource "migadu_domain" "example" {
 resource "migadu_domain_activation" "example_activated" {
   domain_name = migadu_domain.example.name
 }
+
+# Wait for DNS records created in the same apply to propagate
+resource "migadu_domain_activation" "example_waiting" {
+  domain_name  = migadu_domain.example.name
+  wait_for_dns = true
+
+  timeouts {
+    create = "1h"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = migadu_domain_catchall.example
+  id = "example.com"
+}
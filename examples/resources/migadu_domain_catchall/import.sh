@@ -0,0 +1,2 @@
+# The import ID is the domain name.
+terraform import migadu_domain_catchall.example example.com
@@ -0,0 +1,4 @@
+resource "migadu_domain_catchall" "example" {
+  domain_name  = "example.com"
+  destinations = ["catchall@example.com"]
+}
@@ -0,0 +1,8 @@
+import {
+  to = migadu_forwarding.example
+  identity = {
+    domain_name = "example.com"
+    mailbox     = "user"
+    address     = "user@example.org"
+  }
+}
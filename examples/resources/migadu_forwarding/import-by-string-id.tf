@@ -0,0 +1,4 @@
+import {
+  to = migadu_forwarding.example
+  id = "example.com/user/user@example.org"
+}
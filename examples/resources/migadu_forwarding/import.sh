@@ -0,0 +1,3 @@
+# The import ID is domain_name/mailbox/address, or the mailbox address and the destination address separated by a slash.
+terraform import migadu_forwarding.example example.com/user/user@example.org
+terraform import migadu_forwarding.example user@example.com/user@example.org
@@ -0,0 +1,17 @@
+resource "migadu_forwarding" "example" {
+  domain_name = "example.com"
+  mailbox     = "user"
+  address     = "user@example.org"
+}
+
+# Example of a temporary forwarding that waits for the recipient to confirm it
+resource "migadu_forwarding" "holiday" {
+  domain_name = "example.com"
+  mailbox     = "user"
+  address     = "deputy@example.org"
+
+  expires_on         = "2030-08-31"
+  remove_upon_expiry = true
+
+  wait_for_confirmation = true
+}
@@ -0,0 +1,8 @@
+import {
+  to = migadu_identity.example
+  identity = {
+    domain_name = "example.com"
+    mailbox     = "user"
+    local_part  = "alias"
+  }
+}
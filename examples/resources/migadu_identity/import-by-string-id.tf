@@ -0,0 +1,4 @@
+import {
+  to = migadu_identity.example
+  id = "example.com/user/alias"
+}
@@ -0,0 +1,3 @@
+# The import ID is domain_name/mailbox/local_part, or the mailbox address and the identity address separated by a slash.
+terraform import migadu_identity.example example.com/user/alias
+terraform import migadu_identity.example user@example.com/alias@example.com
@@ -0,0 +1,4 @@
+import {
+  to = migadu_mailbox.example
+  id = "example.com/user"
+}
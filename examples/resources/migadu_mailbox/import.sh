@@ -0,0 +1,3 @@
+# The import ID is domain_name/local_part or the email address.
+terraform import migadu_mailbox.example example.com/user
+terraform import migadu_mailbox.example user@example.com
@@ -34,3 +34,14 @@ resource "migadu_mailbox" "invitation" {
   password_method         = "invitation"
   password_recovery_email = "recovery@otherdomain.com"
 }
+
+# Example with a write-only password that is never stored in state
+resource "migadu_mailbox" "write_only" {
+  domain_name = "example.com"
+  local_part  = "secure"
+  name        = "Secure User"
+
+  password_method     = "password"
+  password_wo         = var.mailbox_password
+  password_wo_version = 1
+}
@@ -0,0 +1,7 @@
+import {
+  to = migadu_mailbox_autoresponder.example
+  identity = {
+    domain_name = "example.com"
+    local_part  = "user"
+  }
+}
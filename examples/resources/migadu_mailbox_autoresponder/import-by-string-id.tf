@@ -0,0 +1,4 @@
+import {
+  to = migadu_mailbox_autoresponder.example
+  id = "example.com/user"
+}
@@ -0,0 +1,3 @@
+# The import ID is domain_name/local_part of the mailbox, or its email address.
+terraform import migadu_mailbox_autoresponder.example example.com/user
+terraform import migadu_mailbox_autoresponder.example user@example.com
@@ -0,0 +1,7 @@
+resource "migadu_mailbox_autoresponder" "example" {
+  domain_name = "example.com"
+  local_part  = "user"
+  subject     = "Out of office"
+  body        = "I am out of the office until September 1st and will reply when I am back."
+  expires_on  = "2030-08-31"
+}
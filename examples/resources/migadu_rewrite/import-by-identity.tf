@@ -0,0 +1,7 @@
+import {
+  to = migadu_rewrite.example
+  identity = {
+    domain_name = "example.com"
+    name        = "catch-support"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = migadu_rewrite.example
+  id = "example.com/catch-support"
+}
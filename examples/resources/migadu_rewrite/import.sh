@@ -0,0 +1,2 @@
+# The import ID is domain_name/name.
+terraform import migadu_rewrite.example example.com/catch-support
@@ -0,0 +1,117 @@
+package migadutest
+
+import (
+	"net/http"
+)
+
+// Alias is the wire representation of a Migadu address alias.
+type Alias struct {
+	LocalPart        string     `json:"local_part"`
+	DomainName       string     `json:"domain_name"`
+	Address          string     `json:"address"`
+	Destinations     StringList `json:"destinations"`
+	IsInternal       bool       `json:"is_internal"`
+	Expireable       bool       `json:"expireable"`
+	ExpiresOn        string     `json:"expires_on"`
+	RemoveUponExpiry bool       `json:"remove_upon_expiry"`
+}
+
+func (s *Server) listAliases(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	aliases := make([]Alias, 0, len(d.Aliases))
+	for _, localPart := range sortedKeys(d.Aliases) {
+		aliases = append(aliases, *d.Aliases[localPart])
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"address_aliases": aliases})
+}
+
+func (s *Server) createAlias(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	var a Alias
+	if !decode(w, r, &a) {
+		return
+	}
+	if a.LocalPart == "" {
+		writeError(w, http.StatusUnprocessableEntity, "local_part can't be blank")
+		return
+	}
+	if len(a.Destinations) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "destinations can't be blank")
+		return
+	}
+	if _, ok := d.Aliases[a.LocalPart]; ok {
+		writeError(w, http.StatusConflict, "Address already exists")
+		return
+	}
+	if _, ok := d.Mailboxes[a.LocalPart]; ok {
+		writeError(w, http.StatusConflict, "Address already exists")
+		return
+	}
+
+	a.DomainName = d.Domain.Name
+	a.Address = a.LocalPart + "@" + d.Domain.Name
+	a.Destinations = a.Destinations.normalizeAddresses()
+	d.Aliases[a.LocalPart] = &a
+	writeJSON(w, http.StatusOK, a)
+}
+
+func (s *Server) lookupAlias(w http.ResponseWriter, r *http.Request) (*domainState, *Alias) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return nil, nil
+	}
+
+	a, ok := d.Aliases[r.PathValue("local_part")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Alias not found")
+		return nil, nil
+	}
+	return d, a
+}
+
+func (s *Server) getAlias(w http.ResponseWriter, r *http.Request) {
+	if _, a := s.lookupAlias(w, r); a != nil {
+		writeJSON(w, http.StatusOK, a)
+	}
+}
+
+func (s *Server) updateAlias(w http.ResponseWriter, r *http.Request) {
+	_, a := s.lookupAlias(w, r)
+	if a == nil {
+		return
+	}
+
+	updated := *a
+	if !decode(w, r, &updated) {
+		return
+	}
+	if len(updated.Destinations) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "destinations can't be blank")
+		return
+	}
+
+	updated.LocalPart = a.LocalPart
+	updated.DomainName = a.DomainName
+	updated.Address = a.Address
+	updated.Destinations = updated.Destinations.normalizeAddresses()
+	*a = updated
+	writeJSON(w, http.StatusOK, a)
+}
+
+func (s *Server) deleteAlias(w http.ResponseWriter, r *http.Request) {
+	d, a := s.lookupAlias(w, r)
+	if a == nil {
+		return
+	}
+
+	delete(d.Aliases, a.LocalPart)
+	writeJSON(w, http.StatusOK, a)
+}
@@ -0,0 +1,155 @@
+package migadutest
+
+import (
+	"net/http"
+)
+
+// Domain is the wire representation of a Migadu domain.
+type Domain struct {
+	Name                 string     `json:"name"`
+	State                string     `json:"state"`
+	Description          string     `json:"description"`
+	Tags                 StringList `json:"tags"`
+	SpamAggressiveness   string     `json:"spam_aggressiveness"`
+	GreylistingEnabled   bool       `json:"greylisting_enabled"`
+	MXProxyEnabled       bool       `json:"mx_proxy_enabled"`
+	HostedDNS            bool       `json:"hosted_dns"`
+	SenderAllowlist      StringList `json:"sender_allowlist"`
+	SenderDenylist       StringList `json:"sender_denylist"`
+	RecipientDenylist    StringList `json:"recipient_denylist"`
+	CatchallDestinations StringList `json:"catchall_destinations"`
+}
+
+func (d *Domain) normalize() {
+	d.Tags = d.Tags.normalize()
+	d.SenderAllowlist = d.SenderAllowlist.normalizeAddresses()
+	d.SenderDenylist = d.SenderDenylist.normalizeAddresses()
+	d.RecipientDenylist = d.RecipientDenylist.normalizeAddresses()
+	d.CatchallDestinations = d.CatchallDestinations.normalizeAddresses()
+}
+
+// Diagnostics lists outstanding DNS problems per record type. Empty lists mean
+// the record is correctly configured.
+type Diagnostics struct {
+	MX    StringList `json:"mx"`
+	SPF   StringList `json:"spf"`
+	DKIM  StringList `json:"dkim"`
+	DMARC StringList `json:"dmarc"`
+}
+
+func (d Diagnostics) normalize() Diagnostics {
+	return Diagnostics{
+		MX:    d.MX.normalize(),
+		SPF:   d.SPF.normalize(),
+		DKIM:  d.DKIM.normalize(),
+		DMARC: d.DMARC.normalize(),
+	}
+}
+
+// Record is a DNS record required by a Migadu domain.
+type Record struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Priority int    `json:"priority"`
+	TTL      int    `json:"ttl"`
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request) {
+	domains := make([]Domain, 0, len(s.domains))
+	for _, name := range sortedKeys(s.domains) {
+		domains = append(domains, s.domains[name].Domain)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"domains": domains})
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, r *http.Request) {
+	var d Domain
+	if !decode(w, r, &d) {
+		return
+	}
+	if d.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name can't be blank")
+		return
+	}
+	if d.HostedDNS {
+		writeError(w, http.StatusUnprocessableEntity, "hosted_dns is no longer supported")
+		return
+	}
+	if _, ok := s.domains[d.Name]; ok {
+		writeError(w, http.StatusConflict, "Domain already exists")
+		return
+	}
+
+	d.State = ""
+	state := newDomainState(d)
+	s.domains[d.Name] = state
+	writeJSON(w, http.StatusOK, state.Domain)
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request) {
+	if d := s.lookupDomain(w, r); d != nil {
+		writeJSON(w, http.StatusOK, d.Domain)
+	}
+}
+
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	updated := d.Domain
+	if !decode(w, r, &updated) {
+		return
+	}
+	if updated.HostedDNS {
+		writeError(w, http.StatusUnprocessableEntity, "hosted_dns is no longer supported")
+		return
+	}
+
+	updated.Name = d.Domain.Name
+	updated.State = d.Domain.State
+	updated.normalize()
+	d.Domain = updated
+	writeJSON(w, http.StatusOK, d.Domain)
+}
+
+func (s *Server) getRecords(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	name := d.Domain.Name
+	records := []Record{
+		{Type: "MX", Name: name, Value: "aspmx1.migadu.com", Priority: 10, TTL: 3600},
+		{Type: "MX", Name: name, Value: "aspmx2.migadu.com", Priority: 20, TTL: 3600},
+		{Type: "TXT", Name: name, Value: "v=spf1 include:spf.migadu.com -all", TTL: 3600},
+		{Type: "CNAME", Name: "key1._domainkey." + name, Value: "key1." + name + "._domainkey.migadu.com", TTL: 3600},
+		{Type: "TXT", Name: "_dmarc." + name, Value: "v=DMARC1; p=quarantine;", TTL: 3600},
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"records": records})
+}
+
+func (s *Server) getDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if d := s.lookupDomain(w, r); d != nil {
+		writeJSON(w, http.StatusOK, d.Diagnostics)
+	}
+}
+
+func (s *Server) activateDomain(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	diag := d.Diagnostics
+	if len(diag.MX)+len(diag.SPF)+len(diag.DKIM)+len(diag.DMARC) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "DNS records are not valid")
+		return
+	}
+
+	d.Domain.State = "active"
+	writeJSON(w, http.StatusOK, d.Domain)
+}
@@ -0,0 +1,360 @@
+package migadutest
+
+import (
+	"net/http"
+)
+
+// Mailbox is the wire representation of a Migadu mailbox.
+type Mailbox struct {
+	LocalPart             string     `json:"local_part"`
+	DomainName            string     `json:"domain_name"`
+	Address               string     `json:"address"`
+	Name                  string     `json:"name"`
+	IsInternal            bool       `json:"is_internal"`
+	MaySend               bool       `json:"may_send"`
+	MayReceive            bool       `json:"may_receive"`
+	MayAccessImap         bool       `json:"may_access_imap"`
+	MayAccessPop3         bool       `json:"may_access_pop3"`
+	MayAccessManagesieve  bool       `json:"may_access_managesieve"`
+	PasswordMethod        string     `json:"password_method,omitempty"`
+	Password              string     `json:"password,omitempty"`
+	PasswordRecoveryEmail string     `json:"password_recovery_email"`
+	SpamAction            string     `json:"spam_action"`
+	SpamAggressiveness    string     `json:"spam_aggressiveness"`
+	SenderDenylist        StringList `json:"sender_denylist"`
+	SenderAllowlist       StringList `json:"sender_allowlist"`
+	RecipientDenylist     StringList `json:"recipient_denylist"`
+	AutorespondActive     bool       `json:"autorespond_active"`
+	AutorespondSubject    string     `json:"autorespond_subject"`
+	AutorespondBody       string     `json:"autorespond_body"`
+	AutorespondExpiresOn  string     `json:"autorespond_expires_on"`
+	FooterActive          bool       `json:"footer_active"`
+	FooterPlainBody       string     `json:"footer_plain_body"`
+	FooterHTMLBody        string     `json:"footer_html_body"`
+	StorageUsage          float64    `json:"storage_usage"`
+	Expireable            bool       `json:"expireable"`
+	ExpiresOn             string     `json:"expires_on"`
+	RemoveUponExpiry      bool       `json:"remove_upon_expiry"`
+	ChangedAt             string     `json:"changed_at"`
+	LastLoginAt           string     `json:"last_login_at"`
+}
+
+// response strips the write-only password before a mailbox is returned.
+func (m Mailbox) response() Mailbox {
+	m.Password = ""
+	return m
+}
+
+// Identity is the wire representation of a Migadu mailbox identity.
+type Identity struct {
+	LocalPart            string `json:"local_part"`
+	DomainName           string `json:"domain_name"`
+	Address              string `json:"address"`
+	Name                 string `json:"name"`
+	Password             string `json:"password,omitempty"`
+	MaySend              bool   `json:"may_send"`
+	MayReceive           bool   `json:"may_receive"`
+	MayAccessImap        bool   `json:"may_access_imap"`
+	MayAccessPop3        bool   `json:"may_access_pop3"`
+	MayAccessManagesieve bool   `json:"may_access_managesieve"`
+}
+
+func (i Identity) response() Identity {
+	i.Password = ""
+	return i
+}
+
+// Forwarding is the wire representation of an external mailbox forwarding.
+type Forwarding struct {
+	Address            string `json:"address"`
+	BlockedAt          string `json:"blocked_at"`
+	ConfirmationSentAt string `json:"confirmation_sent_at"`
+	ConfirmedAt        string `json:"confirmed_at"`
+	ExpiresOn          string `json:"expires_on"`
+	IsActive           bool   `json:"is_active"`
+	RemoveUponExpiry   bool   `json:"remove_upon_expiry"`
+}
+
+func (s *Server) listMailboxes(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	mailboxes := make([]Mailbox, 0, len(d.Mailboxes))
+	for _, localPart := range sortedKeys(d.Mailboxes) {
+		mailboxes = append(mailboxes, d.Mailboxes[localPart].Mailbox.response())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"mailboxes": mailboxes})
+}
+
+func (s *Server) createMailbox(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	m := Mailbox{
+		MaySend:              true,
+		MayReceive:           true,
+		MayAccessImap:        true,
+		MayAccessPop3:        true,
+		MayAccessManagesieve: true,
+		SpamAction:           "folder",
+		SpamAggressiveness:   "default",
+	}
+	if !decode(w, r, &m) {
+		return
+	}
+	if m.LocalPart == "" {
+		writeError(w, http.StatusUnprocessableEntity, "local_part can't be blank")
+		return
+	}
+	if m.PasswordMethod == "password" && m.Password == "" {
+		writeError(w, http.StatusUnprocessableEntity, "password can't be blank")
+		return
+	}
+	if m.PasswordMethod == "invitation" && m.PasswordRecoveryEmail == "" {
+		writeError(w, http.StatusUnprocessableEntity, "password_recovery_email can't be blank")
+		return
+	}
+	if _, ok := d.Mailboxes[m.LocalPart]; ok {
+		writeError(w, http.StatusConflict, "Address already exists")
+		return
+	}
+	if _, ok := d.Aliases[m.LocalPart]; ok {
+		writeError(w, http.StatusConflict, "Address already exists")
+		return
+	}
+
+	s.saveMailbox(d, &mailboxState{
+		Mailbox:     m,
+		Identities:  map[string]*Identity{},
+		Forwardings: map[string]*Forwarding{},
+	})
+	writeJSON(w, http.StatusOK, d.Mailboxes[m.LocalPart].Mailbox.response())
+}
+
+func (s *Server) getMailbox(w http.ResponseWriter, r *http.Request) {
+	if _, m := s.lookupMailbox(w, r); m != nil {
+		writeJSON(w, http.StatusOK, m.Mailbox.response())
+	}
+}
+
+func (s *Server) updateMailbox(w http.ResponseWriter, r *http.Request) {
+	d, m := s.lookupMailbox(w, r)
+	if m == nil {
+		return
+	}
+
+	updated := m.Mailbox
+	if !decode(w, r, &updated) {
+		return
+	}
+
+	updated.LocalPart = m.Mailbox.LocalPart
+	m.Mailbox = updated
+	s.saveMailbox(d, m)
+	writeJSON(w, http.StatusOK, m.Mailbox.response())
+}
+
+func (s *Server) deleteMailbox(w http.ResponseWriter, r *http.Request) {
+	d, m := s.lookupMailbox(w, r)
+	if m == nil {
+		return
+	}
+
+	delete(d.Mailboxes, m.Mailbox.LocalPart)
+	writeJSON(w, http.StatusOK, m.Mailbox.response())
+}
+
+// saveMailbox fills in server-managed mailbox fields and stores it.
+func (s *Server) saveMailbox(d *domainState, m *mailboxState) {
+	m.Mailbox.DomainName = d.Domain.Name
+	m.Mailbox.Address = m.Mailbox.LocalPart + "@" + d.Domain.Name
+	m.Mailbox.SenderAllowlist = m.Mailbox.SenderAllowlist.normalizeAddresses()
+	m.Mailbox.SenderDenylist = m.Mailbox.SenderDenylist.normalizeAddresses()
+	m.Mailbox.RecipientDenylist = m.Mailbox.RecipientDenylist.normalizeAddresses()
+	m.Mailbox.ChangedAt = now()
+	d.Mailboxes[m.Mailbox.LocalPart] = m
+}
+
+func (s *Server) listIdentities(w http.ResponseWriter, r *http.Request) {
+	_, m := s.lookupMailbox(w, r)
+	if m == nil {
+		return
+	}
+
+	identities := make([]Identity, 0, len(m.Identities))
+	for _, localPart := range sortedKeys(m.Identities) {
+		identities = append(identities, m.Identities[localPart].response())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"identities": identities})
+}
+
+func (s *Server) createIdentity(w http.ResponseWriter, r *http.Request) {
+	d, m := s.lookupMailbox(w, r)
+	if m == nil {
+		return
+	}
+
+	i := Identity{
+		MaySend:              true,
+		MayReceive:           true,
+		MayAccessImap:        true,
+		MayAccessPop3:        true,
+		MayAccessManagesieve: true,
+	}
+	if !decode(w, r, &i) {
+		return
+	}
+	if i.LocalPart == "" {
+		writeError(w, http.StatusUnprocessableEntity, "local_part can't be blank")
+		return
+	}
+	if _, ok := m.Identities[i.LocalPart]; ok {
+		writeError(w, http.StatusConflict, "Identity already exists")
+		return
+	}
+
+	i.DomainName = d.Domain.Name
+	i.Address = i.LocalPart + "@" + d.Domain.Name
+	m.Identities[i.LocalPart] = &i
+	writeJSON(w, http.StatusOK, i.response())
+}
+
+func (s *Server) lookupIdentity(w http.ResponseWriter, r *http.Request) (*mailboxState, *Identity) {
+	_, m := s.lookupMailbox(w, r)
+	if m == nil {
+		return nil, nil
+	}
+
+	i, ok := m.Identities[r.PathValue("identity")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Identity not found")
+		return nil, nil
+	}
+	return m, i
+}
+
+func (s *Server) getIdentity(w http.ResponseWriter, r *http.Request) {
+	if _, i := s.lookupIdentity(w, r); i != nil {
+		writeJSON(w, http.StatusOK, i.response())
+	}
+}
+
+func (s *Server) updateIdentity(w http.ResponseWriter, r *http.Request) {
+	_, i := s.lookupIdentity(w, r)
+	if i == nil {
+		return
+	}
+
+	updated := *i
+	if !decode(w, r, &updated) {
+		return
+	}
+
+	updated.LocalPart = i.LocalPart
+	updated.DomainName = i.DomainName
+	updated.Address = i.Address
+	*i = updated
+	writeJSON(w, http.StatusOK, i.response())
+}
+
+func (s *Server) deleteIdentity(w http.ResponseWriter, r *http.Request) {
+	m, i := s.lookupIdentity(w, r)
+	if i == nil {
+		return
+	}
+
+	delete(m.Identities, i.LocalPart)
+	writeJSON(w, http.StatusOK, i.response())
+}
+
+func (s *Server) listForwardings(w http.ResponseWriter, r *http.Request) {
+	_, m := s.lookupMailbox(w, r)
+	if m == nil {
+		return
+	}
+
+	forwardings := make([]Forwarding, 0, len(m.Forwardings))
+	for _, address := range sortedKeys(m.Forwardings) {
+		forwardings = append(forwardings, *m.Forwardings[address])
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"forwardings": forwardings})
+}
+
+func (s *Server) createForwarding(w http.ResponseWriter, r *http.Request) {
+	_, m := s.lookupMailbox(w, r)
+	if m == nil {
+		return
+	}
+
+	f := Forwarding{IsActive: true}
+	if !decode(w, r, &f) {
+		return
+	}
+	if f.Address == "" {
+		writeError(w, http.StatusUnprocessableEntity, "address can't be blank")
+		return
+	}
+	if _, ok := m.Forwardings[f.Address]; ok {
+		writeError(w, http.StatusConflict, "Forwarding already exists")
+		return
+	}
+
+	f.BlockedAt = ""
+	f.ConfirmedAt = ""
+	f.ConfirmationSentAt = now()
+	m.Forwardings[f.Address] = &f
+	writeJSON(w, http.StatusOK, f)
+}
+
+func (s *Server) lookupForwarding(w http.ResponseWriter, r *http.Request) (*mailboxState, *Forwarding) {
+	_, m := s.lookupMailbox(w, r)
+	if m == nil {
+		return nil, nil
+	}
+
+	f, ok := m.Forwardings[r.PathValue("address")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Forwarding not found")
+		return nil, nil
+	}
+	return m, f
+}
+
+func (s *Server) getForwarding(w http.ResponseWriter, r *http.Request) {
+	if _, f := s.lookupForwarding(w, r); f != nil {
+		writeJSON(w, http.StatusOK, f)
+	}
+}
+
+func (s *Server) updateForwarding(w http.ResponseWriter, r *http.Request) {
+	_, f := s.lookupForwarding(w, r)
+	if f == nil {
+		return
+	}
+
+	updated := *f
+	if !decode(w, r, &updated) {
+		return
+	}
+
+	updated.Address = f.Address
+	updated.BlockedAt = f.BlockedAt
+	updated.ConfirmationSentAt = f.ConfirmationSentAt
+	updated.ConfirmedAt = f.ConfirmedAt
+	*f = updated
+	writeJSON(w, http.StatusOK, f)
+}
+
+func (s *Server) deleteForwarding(w http.ResponseWriter, r *http.Request) {
+	m, f := s.lookupForwarding(w, r)
+	if f == nil {
+		return
+	}
+
+	delete(m.Forwardings, f.Address)
+	writeJSON(w, http.StatusOK, f)
+}
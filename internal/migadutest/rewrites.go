@@ -0,0 +1,104 @@
+package migadutest
+
+import (
+	"net/http"
+)
+
+// Rewrite is the wire representation of a Migadu rewrite rule.
+type Rewrite struct {
+	Name          string     `json:"name"`
+	DomainName    string     `json:"domain_name"`
+	LocalPartRule string     `json:"local_part_rule"`
+	OrderNum      int        `json:"order_num"`
+	Destinations  StringList `json:"destinations"`
+}
+
+func (s *Server) listRewrites(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	rewrites := make([]Rewrite, 0, len(d.Rewrites))
+	for _, name := range sortedKeys(d.Rewrites) {
+		rewrites = append(rewrites, *d.Rewrites[name])
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"rewrites": rewrites})
+}
+
+func (s *Server) createRewrite(w http.ResponseWriter, r *http.Request) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return
+	}
+
+	var rw Rewrite
+	if !decode(w, r, &rw) {
+		return
+	}
+	if rw.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name can't be blank")
+		return
+	}
+	if rw.LocalPartRule == "" {
+		writeError(w, http.StatusUnprocessableEntity, "local_part_rule can't be blank")
+		return
+	}
+	if _, ok := d.Rewrites[rw.Name]; ok {
+		writeError(w, http.StatusConflict, "Rewrite already exists")
+		return
+	}
+
+	rw.DomainName = d.Domain.Name
+	rw.Destinations = rw.Destinations.normalizeAddresses()
+	d.Rewrites[rw.Name] = &rw
+	writeJSON(w, http.StatusOK, rw)
+}
+
+func (s *Server) lookupRewrite(w http.ResponseWriter, r *http.Request) (*domainState, *Rewrite) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return nil, nil
+	}
+
+	rw, ok := d.Rewrites[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Rewrite not found")
+		return nil, nil
+	}
+	return d, rw
+}
+
+func (s *Server) getRewrite(w http.ResponseWriter, r *http.Request) {
+	if _, rw := s.lookupRewrite(w, r); rw != nil {
+		writeJSON(w, http.StatusOK, rw)
+	}
+}
+
+func (s *Server) updateRewrite(w http.ResponseWriter, r *http.Request) {
+	_, rw := s.lookupRewrite(w, r)
+	if rw == nil {
+		return
+	}
+
+	updated := *rw
+	if !decode(w, r, &updated) {
+		return
+	}
+
+	updated.Name = rw.Name
+	updated.DomainName = rw.DomainName
+	updated.Destinations = updated.Destinations.normalizeAddresses()
+	*rw = updated
+	writeJSON(w, http.StatusOK, rw)
+}
+
+func (s *Server) deleteRewrite(w http.ResponseWriter, r *http.Request) {
+	d, rw := s.lookupRewrite(w, r)
+	if rw == nil {
+		return
+	}
+
+	delete(d.Rewrites, rw.Name)
+	writeJSON(w, http.StatusOK, rw)
+}
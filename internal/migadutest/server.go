@@ -0,0 +1,347 @@
+// Package migadutest provides an in-memory fake of the Migadu REST API for
+// exercising the provider without network access or real credentials.
+package migadutest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Username and APIKey are the credentials accepted by a Server.
+const (
+	Username = "admin@example.com"
+	APIKey   = "migadutest-api-key"
+)
+
+// Server is a fake Migadu API backed by an httptest.Server. All state is held
+// in memory and discarded when the server is closed.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	domains map[string]*domainState
+}
+
+// NewServer starts a fake Migadu API and registers its shutdown with t.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{domains: map[string]*domainState{}}
+	s.Server = httptest.NewServer(s.routes())
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Endpoint returns the API base URL to configure the provider or client with.
+func (s *Server) Endpoint() string {
+	return s.URL + "/v1"
+}
+
+// AddDomain seeds an active domain with default settings.
+func (s *Server) AddDomain(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := newDomainState(Domain{Name: name})
+	d.Domain.State = "active"
+	s.domains[name] = d
+}
+
+// SetDiagnostics replaces the DNS diagnostics reported for a domain.
+func (s *Server) SetDiagnostics(domainName string, diagnostics Diagnostics) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[domainName]
+	if !ok {
+		return fmt.Errorf("migadutest: unknown domain %q", domainName)
+	}
+	d.Diagnostics = diagnostics.normalize()
+	return nil
+}
+
+// MailboxPassword returns the password last set on a mailbox.
+func (s *Server) MailboxPassword(domainName, localPart string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[domainName]
+	if !ok {
+		return "", false
+	}
+	m, ok := d.Mailboxes[localPart]
+	if !ok {
+		return "", false
+	}
+	return m.Mailbox.Password, true
+}
+
+// IdentityPassword returns the password last set on a mailbox identity.
+func (s *Server) IdentityPassword(domainName, mailbox, localPart string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[domainName]
+	if !ok {
+		return "", false
+	}
+	m, ok := d.Mailboxes[mailbox]
+	if !ok {
+		return "", false
+	}
+	i, ok := m.Identities[localPart]
+	if !ok {
+		return "", false
+	}
+	return i.Password, true
+}
+
+// ConfirmForwarding marks a mailbox forwarding as confirmed by its recipient.
+func (s *Server) ConfirmForwarding(domainName, mailbox, address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[domainName]
+	if !ok {
+		return fmt.Errorf("migadutest: unknown domain %q", domainName)
+	}
+	m, ok := d.Mailboxes[mailbox]
+	if !ok {
+		return fmt.Errorf("migadutest: unknown mailbox %q", mailbox)
+	}
+	f, ok := m.Forwardings[address]
+	if !ok {
+		return fmt.Errorf("migadutest: unknown forwarding %q", address)
+	}
+	f.ConfirmedAt = now()
+	return nil
+}
+
+// RemoveExpired deletes the mailboxes and aliases that are set to be removed
+// upon expiry and expire on or before date, as Migadu does once a day.
+func (s *Server) RemoveExpired(date string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, d := range s.domains {
+		for localPart, m := range d.Mailboxes {
+			if m.Mailbox.Expireable && m.Mailbox.RemoveUponExpiry && m.Mailbox.ExpiresOn != "" && m.Mailbox.ExpiresOn <= date {
+				delete(d.Mailboxes, localPart)
+			}
+		}
+		for localPart, a := range d.Aliases {
+			if a.Expireable && a.RemoveUponExpiry && a.ExpiresOn != "" && a.ExpiresOn <= date {
+				delete(d.Aliases, localPart)
+			}
+		}
+	}
+}
+
+type domainState struct {
+	Domain      Domain
+	Diagnostics Diagnostics
+	Mailboxes   map[string]*mailboxState
+	Aliases     map[string]*Alias
+	Rewrites    map[string]*Rewrite
+}
+
+type mailboxState struct {
+	Mailbox     Mailbox
+	Identities  map[string]*Identity
+	Forwardings map[string]*Forwarding
+}
+
+func newDomainState(d Domain) *domainState {
+	if d.SpamAggressiveness == "" {
+		d.SpamAggressiveness = "default"
+	}
+	if d.State == "" {
+		d.State = "pending"
+	}
+	d.normalize()
+
+	return &domainState{
+		Domain:      d,
+		Diagnostics: Diagnostics{}.normalize(),
+		Mailboxes:   map[string]*mailboxState{},
+		Aliases:     map[string]*Alias{},
+		Rewrites:    map[string]*Rewrite{},
+	}
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v1/domains", s.listDomains)
+	mux.HandleFunc("POST /v1/domains", s.createDomain)
+	mux.HandleFunc("GET /v1/domains/{domain}", s.getDomain)
+	mux.HandleFunc("PUT /v1/domains/{domain}", s.updateDomain)
+	mux.HandleFunc("GET /v1/domains/{domain}/records", s.getRecords)
+	mux.HandleFunc("GET /v1/domains/{domain}/diagnostics", s.getDiagnostics)
+	mux.HandleFunc("GET /v1/domains/{domain}/activate", s.activateDomain)
+
+	mux.HandleFunc("GET /v1/domains/{domain}/mailboxes", s.listMailboxes)
+	mux.HandleFunc("POST /v1/domains/{domain}/mailboxes", s.createMailbox)
+	mux.HandleFunc("GET /v1/domains/{domain}/mailboxes/{local_part}", s.getMailbox)
+	mux.HandleFunc("PUT /v1/domains/{domain}/mailboxes/{local_part}", s.updateMailbox)
+	mux.HandleFunc("DELETE /v1/domains/{domain}/mailboxes/{local_part}", s.deleteMailbox)
+
+	mux.HandleFunc("GET /v1/domains/{domain}/mailboxes/{local_part}/identities", s.listIdentities)
+	mux.HandleFunc("POST /v1/domains/{domain}/mailboxes/{local_part}/identities", s.createIdentity)
+	mux.HandleFunc("GET /v1/domains/{domain}/mailboxes/{local_part}/identities/{identity}", s.getIdentity)
+	mux.HandleFunc("PUT /v1/domains/{domain}/mailboxes/{local_part}/identities/{identity}", s.updateIdentity)
+	mux.HandleFunc("DELETE /v1/domains/{domain}/mailboxes/{local_part}/identities/{identity}", s.deleteIdentity)
+
+	mux.HandleFunc("GET /v1/domains/{domain}/mailboxes/{local_part}/forwardings", s.listForwardings)
+	mux.HandleFunc("POST /v1/domains/{domain}/mailboxes/{local_part}/forwardings", s.createForwarding)
+	mux.HandleFunc("GET /v1/domains/{domain}/mailboxes/{local_part}/forwardings/{address}", s.getForwarding)
+	mux.HandleFunc("PUT /v1/domains/{domain}/mailboxes/{local_part}/forwardings/{address}", s.updateForwarding)
+	mux.HandleFunc("DELETE /v1/domains/{domain}/mailboxes/{local_part}/forwardings/{address}", s.deleteForwarding)
+
+	mux.HandleFunc("GET /v1/domains/{domain}/aliases", s.listAliases)
+	mux.HandleFunc("POST /v1/domains/{domain}/aliases", s.createAlias)
+	mux.HandleFunc("GET /v1/domains/{domain}/aliases/{local_part}", s.getAlias)
+	mux.HandleFunc("PUT /v1/domains/{domain}/aliases/{local_part}", s.updateAlias)
+	mux.HandleFunc("DELETE /v1/domains/{domain}/aliases/{local_part}", s.deleteAlias)
+
+	mux.HandleFunc("GET /v1/domains/{domain}/rewrites", s.listRewrites)
+	mux.HandleFunc("POST /v1/domains/{domain}/rewrites", s.createRewrite)
+	mux.HandleFunc("GET /v1/domains/{domain}/rewrites/{name}", s.getRewrite)
+	mux.HandleFunc("PUT /v1/domains/{domain}/rewrites/{name}", s.updateRewrite)
+	mux.HandleFunc("DELETE /v1/domains/{domain}/rewrites/{name}", s.deleteRewrite)
+
+	return s.authenticate(mux)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, apiKey, ok := r.BasicAuth()
+		if !ok || username != Username || apiKey != APIKey {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// lookupDomain returns the domain named in the request path, writing a 404
+// response and returning nil if it does not exist. Callers must hold s.mu.
+func (s *Server) lookupDomain(w http.ResponseWriter, r *http.Request) *domainState {
+	d, ok := s.domains[r.PathValue("domain")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Domain not found")
+		return nil
+	}
+	return d
+}
+
+// lookupMailbox returns the mailbox named in the request path, writing a 404
+// response and returning nil if it does not exist. Callers must hold s.mu.
+func (s *Server) lookupMailbox(w http.ResponseWriter, r *http.Request) (*domainState, *mailboxState) {
+	d := s.lookupDomain(w, r)
+	if d == nil {
+		return nil, nil
+	}
+
+	m, ok := d.Mailboxes[r.PathValue("local_part")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Mailbox not found")
+		return nil, nil
+	}
+	return d, m
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// decode reads a JSON request body into v, which may already hold the current
+// object so that fields omitted from the request keep their values.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// StringList decodes either a JSON array of strings or a comma separated
+// string, matching how the Migadu API accepts address lists.
+type StringList []string
+
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*l = list
+		return nil
+	}
+
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return errors.New("expected an array of strings or a comma separated string")
+	}
+
+	*l = StringList{}
+	for _, part := range strings.Split(joined, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*l = append(*l, part)
+		}
+	}
+	return nil
+}
+
+// normalize sorts the list and drops duplicates. Migadu does not keep the
+// order in which list entries were sent, and the fake mimics that so tests
+// catch order-sensitive diffs.
+func (l StringList) normalize() StringList {
+	if l == nil {
+		return StringList{}
+	}
+
+	normalized := slices.Clone(l)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// normalizeAddresses lowercases the addresses in the list and then
+// normalizes it, as Migadu does with address lists.
+func (l StringList) normalizeAddresses() StringList {
+	lowered := make(StringList, 0, len(l))
+	for _, address := range l {
+		lowered = append(lowered, strings.ToLower(address))
+	}
+	return lowered.normalize()
+}
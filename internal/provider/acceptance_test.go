@@ -5,10 +5,15 @@ import (
 	"os"
 	"testing"
 
+	"github.com/MrLemur/migadu-go"
+	"github.com/MrLemur/terraform-provider-migadu/internal/migadutest"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
+// testFakeDomain is the domain seeded into the fake Migadu API.
+const testFakeDomain = "example.com"
+
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"migadu": providerserver.NewProtocol6WithError(New("test")()),
 }
@@ -29,24 +34,72 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
-func testAccMailboxConfig(localPart string) string {
+// testAccSetup points the provider at the real Migadu API when TF_ACC is set
+// and at an in-memory fake otherwise. It returns the domain to test against.
+func testAccSetup(t *testing.T) string {
+	t.Helper()
+
+	if os.Getenv("TF_ACC") != "" {
+		testAccPreCheck(t)
+		return os.Getenv("MIGADU_TEST_DOMAIN")
+	}
+
+	testFakeSetup(t)
+	return testFakeDomain
+}
+
+// testFakeSetup points the provider at an in-memory fake Migadu API seeded
+// with testFakeDomain, for tests that must never run against a real account.
+func testFakeSetup(t *testing.T) *migadutest.Server {
+	t.Helper()
+
+	server := migadutest.NewServer(t)
+	server.AddDomain(testFakeDomain)
+
+	t.Setenv("MIGADU_USERNAME", migadutest.Username)
+	t.Setenv("MIGADU_API_KEY", migadutest.APIKey)
+	t.Setenv("MIGADU_ENDPOINT", server.Endpoint())
+
+	return server
+}
+
+// testAccClient returns a Migadu client for the API selected by testAccSetup,
+// for test steps that need to change objects behind Terraform's back.
+func testAccClient(t *testing.T) *migadu.Client {
+	t.Helper()
+
+	var opts []migadu.Option
+	if endpoint := os.Getenv("MIGADU_ENDPOINT"); endpoint != "" {
+		opts = append(opts, migadu.WithBaseURL(endpoint))
+	}
+
+	client, err := migadu.New(os.Getenv("MIGADU_USERNAME"), os.Getenv("MIGADU_API_KEY"), opts...)
+	if err != nil {
+		t.Fatalf("failed creating Migadu client: %s", err)
+	}
+	return client
+}
+
+func testAccMailboxConfig(domainName, localPart string) string {
 	return fmt.Sprintf(`
 resource "migadu_mailbox" "test" {
-  domain_name     = "%s"
-  local_part      = "%s"
-  name            = "Terraform Acceptance %s"
-  password_method = "invitation"
+  domain_name             = "%s"
+  local_part              = "%s"
+  name                    = "Terraform Acceptance %s"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
 }
-`, os.Getenv("MIGADU_TEST_DOMAIN"), localPart, localPart)
+`, domainName, localPart, localPart)
 }
 
-func testAccAliasConfig(mailboxLocalPart, aliasLocalPart string) string {
+func testAccAliasConfig(domainName, mailboxLocalPart, aliasLocalPart string) string {
 	return fmt.Sprintf(`
 resource "migadu_mailbox" "dest" {
-  domain_name     = "%s"
-  local_part      = "%s"
-  name            = "Terraform Acceptance Destination %s"
-  password_method = "invitation"
+  domain_name             = "%s"
+  local_part              = "%s"
+  name                    = "Terraform Acceptance Destination %s"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
 }
 
 resource "migadu_alias" "test" {
@@ -54,5 +107,5 @@ resource "migadu_alias" "test" {
   local_part   = "%s"
   destinations = [migadu_mailbox.dest.address]
 }
-`, os.Getenv("MIGADU_TEST_DOMAIN"), mailboxLocalPart, mailboxLocalPart, os.Getenv("MIGADU_TEST_DOMAIN"), aliasLocalPart)
+`, domainName, mailboxLocalPart, mailboxLocalPart, domainName, aliasLocalPart)
 }
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// splitAddress splits an email address into its local part and domain, both
+// lowercased.
+func splitAddress(address string) (localPart, domain string, err error) {
+	if err := validateEmailAddress(address); err != nil {
+		return "", "", err
+	}
+
+	localPart, domain, _ = strings.Cut(strings.ToLower(address), "@")
+	return localPart, domain, nil
+}
+
+// importStateByAddress imports a resource addressed by domain_name and
+// local_part from an import ID that is its email address.
+func importStateByAddress(ctx context.Context, id string, resp *resource.ImportStateResponse) {
+	localPart, domain, err := splitAddress(id)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+}
+
+// addressConfigValidators require a resource to be addressed either by
+// address or by domain_name and local_part together.
+func addressConfigValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("address"), path.MatchRoot("domain_name")),
+		resourcevalidator.Conflicting(path.MatchRoot("address"), path.MatchRoot("local_part")),
+		resourcevalidator.RequiredTogether(path.MatchRoot("domain_name"), path.MatchRoot("local_part")),
+	}
+}
+
+// stateAddress returns the address to store in state for the address read
+// from Migadu. A known address that only differs in case or in the form of
+// its domain is kept, since Terraform requires configured and planned values
+// to be stored as written.
+func stateAddress(current EmailAddressValue, address string) EmailAddressValue {
+	if addressToASCII(current.ValueString()) == addressToASCII(address) && !current.IsUnknown() && !current.IsNull() {
+		return current
+	}
+	return NewEmailAddressValue(address)
+}
+
+// appliedAddress is stateAddress for create and update, where planned is
+// the address computed during plan. It reports an error if Migadu created
+// the object under a different address.
+func appliedAddress(diags *diag.Diagnostics, planned EmailAddressValue, address string) EmailAddressValue {
+	if !planned.IsUnknown() && !planned.IsNull() && addressToASCII(planned.ValueString()) != addressToASCII(address) {
+		diags.AddAttributeError(
+			path.Root("address"),
+			"Unexpected Address",
+			fmt.Sprintf("Migadu returned the address %s, but %s was planned. Please report this issue to the provider developers.", address, planned.ValueString()),
+		)
+	}
+	return stateAddress(planned, address)
+}
+
+// addressPart returns a plan modifier that plans domain_name or local_part
+// from the configured address attribute when the attribute itself is not
+// configured.
+func addressPart(part string) planmodifier.String {
+	return addressPartModifier{part: part}
+}
+
+type addressPartModifier struct {
+	part string
+}
+
+func (m addressPartModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Derives the %s from the address attribute when it is set instead.", m.part)
+}
+
+func (m addressPartModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m addressPartModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var address EmailAddressValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("address"), &address)...)
+	if resp.Diagnostics.HasError() || address.IsNull() {
+		return
+	}
+
+	if address.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	localPart, domain, err := splitAddress(address.ValueString())
+	if err != nil {
+		// The address type reports the error during validation.
+		return
+	}
+
+	if m.part == "domain_name" {
+		resp.PlanValue = types.StringValue(domain)
+	} else {
+		resp.PlanValue = types.StringValue(localPart)
+	}
+}
+
+// addressFromParts returns a plan modifier that plans the address as
+// local_part@domain_name when both are configured and known, so that it can
+// be referenced before the object exists.
+func addressFromParts() planmodifier.String {
+	return addressFromPartsModifier{}
+}
+
+type addressFromPartsModifier struct{}
+
+func (m addressFromPartsModifier) Description(ctx context.Context) string {
+	return "Computes the address from local_part and domain_name during plan."
+}
+
+func (m addressFromPartsModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m addressFromPartsModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var domain DomainNameValue
+	var localPart LocalPartValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("domain_name"), &domain)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("local_part"), &localPart)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if domain.IsNull() || domain.IsUnknown() || localPart.IsNull() || localPart.IsUnknown() {
+		return
+	}
+
+	resp.PlanValue = types.StringValue(strings.ToLower(localPart.ValueString() + "@" + domain.ValueString()))
+}
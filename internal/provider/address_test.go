@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitAddress(t *testing.T) {
+	testCases := map[string]struct {
+		address           string
+		expectedLocalPart string
+		expectedDomain    string
+		expectError       bool
+	}{
+		"simple": {
+			address:           "alice@example.com",
+			expectedLocalPart: "alice",
+			expectedDomain:    "example.com",
+		},
+		"lowercased": {
+			address:           "Alice.Smith@Example.COM",
+			expectedLocalPart: "alice.smith",
+			expectedDomain:    "example.com",
+		},
+		"plus addressing": {
+			address:           "alice+news@example.com",
+			expectedLocalPart: "alice+news",
+			expectedDomain:    "example.com",
+		},
+		"missing at": {
+			address:     "example.com",
+			expectError: true,
+		},
+		"empty local part": {
+			address:     "@example.com",
+			expectError: true,
+		},
+		"empty domain": {
+			address:     "alice@",
+			expectError: true,
+		},
+		"two ats": {
+			address:     "alice@bob@example.com",
+			expectError: true,
+		},
+		"whitespace": {
+			address:     "alice @example.com",
+			expectError: true,
+		},
+		"slash": {
+			address:     "example.com/alice@example.com",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			localPart, domain, err := splitAddress(tc.address)
+
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got local part %q and domain %q", localPart, domain)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if localPart != tc.expectedLocalPart || domain != tc.expectedDomain {
+				t.Fatalf("expected (%q, %q), got (%q, %q)", tc.expectedLocalPart, tc.expectedDomain, localPart, domain)
+			}
+		})
+	}
+}
+
+func TestAppliedAddress(t *testing.T) {
+	testCases := map[string]struct {
+		planned     EmailAddressValue
+		address     string
+		expected    string
+		expectError bool
+	}{
+		"unknown": {
+			planned:  EmailAddressValue{StringValue: types.StringUnknown()},
+			address:  "alice@example.com",
+			expected: "alice@example.com",
+		},
+		"matches": {
+			planned:  NewEmailAddressValue("alice@example.com"),
+			address:  "alice@example.com",
+			expected: "alice@example.com",
+		},
+		"differs in case": {
+			planned:  NewEmailAddressValue("Alice@Example.com"),
+			address:  "alice@example.com",
+			expected: "Alice@Example.com",
+		},
+		"differs": {
+			planned:     NewEmailAddressValue("alice@example.com"),
+			address:     "bob@example.com",
+			expected:    "bob@example.com",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := appliedAddress(&diags, tc.planned, tc.address)
+
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error %t, got diagnostics: %v", tc.expectError, diags)
+			}
+			if got.ValueString() != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got.ValueString())
+			}
+		})
+	}
+}
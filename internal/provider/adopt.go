@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// createOrAdopt creates an object with create. When that fails because the
+// object already exists and adopt is true, the existing object is fetched
+// with get and overwritten with update, so that it matches the plan and can
+// be taken into state as if it had just been created.
+func createOrAdopt[T any](ctx context.Context, adopt bool, object string, create, get, update func() (T, error)) (T, error) {
+	created, err := create()
+	if err == nil || !adopt || !isConflictError(err) {
+		return created, err
+	}
+
+	if _, getErr := get(); getErr != nil {
+		if isNotFoundError(getErr) {
+			// The address is taken by a different kind of object, such as
+			// an alias using the address of a new mailbox, which can't be
+			// adopted.
+			return created, err
+		}
+		return created, getErr
+	}
+
+	tflog.Info(ctx, "Adopting existing Migadu object", map[string]any{
+		"object": object,
+	})
+
+	return update()
+}
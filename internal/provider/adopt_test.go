@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/MrLemur/migadu-go"
+)
+
+func TestCreateOrAdopt(t *testing.T) {
+	conflict := &migadu.APIError{StatusCode: http.StatusConflict, Message: "Address already exists"}
+	notFound := &migadu.APIError{StatusCode: http.StatusNotFound, Message: "Not found"}
+	unavailable := &migadu.APIError{StatusCode: http.StatusServiceUnavailable}
+
+	testCases := map[string]struct {
+		adopt           bool
+		createErr       error
+		getErr          error
+		expected        string
+		expectedErr     error
+		expectedUpdates int
+	}{
+		"created": {
+			adopt:    true,
+			expected: "created",
+		},
+		"conflict without adopt": {
+			createErr:   conflict,
+			expectedErr: conflict,
+		},
+		"conflict adopted": {
+			adopt:           true,
+			createErr:       conflict,
+			expected:        "updated",
+			expectedUpdates: 1,
+		},
+		"conflict with another kind of object": {
+			adopt:       true,
+			createErr:   conflict,
+			getErr:      notFound,
+			expectedErr: conflict,
+		},
+		"lookup fails": {
+			adopt:       true,
+			createErr:   conflict,
+			getErr:      unavailable,
+			expectedErr: unavailable,
+		},
+		"other create error": {
+			adopt:       true,
+			createErr:   unavailable,
+			expectedErr: unavailable,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			updates := 0
+			got, err := createOrAdopt(context.Background(), tc.adopt, "alice@example.com",
+				func() (string, error) {
+					if tc.createErr != nil {
+						return "", tc.createErr
+					}
+					return "created", nil
+				},
+				func() (string, error) { return "existing", tc.getErr },
+				func() (string, error) {
+					updates++
+					return "updated", nil
+				},
+			)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+			if updates != tc.expectedUpdates {
+				t.Fatalf("expected %d updates, got %d", tc.expectedUpdates, updates)
+			}
+		})
+	}
+}
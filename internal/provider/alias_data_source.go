@@ -21,11 +21,14 @@ type AliasDataSource struct {
 }
 
 type AliasDataSourceModel struct {
-	DomainName   types.String `tfsdk:"domain_name"`
-	LocalPart    types.String `tfsdk:"local_part"`
-	Address      types.String `tfsdk:"address"`
-	Destinations types.List   `tfsdk:"destinations"`
-	IsInternal   types.Bool   `tfsdk:"is_internal"`
+	DomainName       DomainNameValue `tfsdk:"domain_name"`
+	LocalPart        LocalPartValue  `tfsdk:"local_part"`
+	Address          types.String    `tfsdk:"address"`
+	Destinations     types.List      `tfsdk:"destinations"`
+	IsInternal       types.Bool      `tfsdk:"is_internal"`
+	Expirable        types.Bool      `tfsdk:"expirable"`
+	ExpiresOn        types.String    `tfsdk:"expires_on"`
+	RemoveUponExpiry types.Bool      `tfsdk:"remove_upon_expiry"`
 }
 
 func (d *AliasDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,10 +42,12 @@ func (d *AliasDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"local_part": schema.StringAttribute{
 				MarkdownDescription: "The local part of the email address.",
+				CustomType:          LocalPartType{},
 				Required:            true,
 			},
 			"address": schema.StringAttribute{
@@ -52,12 +57,24 @@ func (d *AliasDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 			"destinations": schema.ListAttribute{
 				MarkdownDescription: "List of destination email addresses.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         EmailAddressType{},
 			},
 			"is_internal": schema.BoolAttribute{
 				MarkdownDescription: "Whether this is an internal alias.",
 				Computed:            true,
 			},
+			"expirable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the alias expires.",
+				Computed:            true,
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "Date on which the alias expires.",
+				Computed:            true,
+			},
+			"remove_upon_expiry": schema.BoolAttribute{
+				MarkdownDescription: "Whether the alias is removed once it expires.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -86,19 +103,22 @@ func (d *AliasDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	localPart := data.LocalPart.ValueString()
 
 	alias, err := d.client.GetAlias(ctx, domain, &migadu.Alias{LocalPart: localPart})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read alias, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "read alias", err)
 		return
 	}
 
 	data.Address = types.StringValue(alias.Address)
 	data.IsInternal = types.BoolValue(alias.IsInternal)
+	data.Expirable = types.BoolValue(alias.Expireable)
+	data.ExpiresOn = types.StringValue(alias.ExpiresOn)
+	data.RemoveUponExpiry = types.BoolValue(alias.RemoveUponExpiry)
 
-	destinations, diags := types.ListValueFrom(ctx, types.StringType, alias.Destinations)
+	destinations, diags := types.ListValueFrom(ctx, EmailAddressType{}, normalizeStringSlice(alias.Destinations))
 	resp.Diagnostics.Append(diags...)
 	data.Destinations = destinations
 
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewAliasDataSourceMetadata(t *testing.T) {
@@ -40,3 +43,29 @@ func TestNewAliasDataSourceSchemaExpectations(t *testing.T) {
 	requireDataSourceListAttribute(t, attrs, "destinations", false, true)
 	requireDataSourceBoolAttributeComputed(t, attrs, "is_internal")
 }
+
+func TestAccAliasDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	suffix := time.Now().UnixNano()
+	mailboxLocalPart := fmt.Sprintf("tfacc-alias-dest-%d", suffix)
+	aliasLocalPart := fmt.Sprintf("tfacc-alias-ds-%d", suffix)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAliasConfig(domainName, mailboxLocalPart, aliasLocalPart) + `
+data "migadu_alias" "test" {
+  domain_name = migadu_alias.test.domain_name
+  local_part  = migadu_alias.test.local_part
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_alias.test", "address", aliasLocalPart+"@"+domainName),
+					resource.TestCheckResourceAttr("data.migadu_alias.test", "destinations.#", "1"),
+					resource.TestCheckResourceAttr("data.migadu_alias.test", "destinations.0", mailboxLocalPart+"@"+domainName),
+				),
+			},
+		},
+	})
+}
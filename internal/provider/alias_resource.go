@@ -6,17 +6,25 @@ import (
 	"strings"
 
 	"github.com/MrLemur/migadu-go"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AliasResource{}
 var _ resource.ResourceWithImportState = &AliasResource{}
+var _ resource.ResourceWithIdentity = &AliasResource{}
+var _ resource.ResourceWithMoveState = &AliasResource{}
+var _ resource.ResourceWithConfigValidators = &AliasResource{}
 
 func NewAliasResource() resource.Resource {
 	return &AliasResource{}
@@ -29,11 +37,22 @@ type AliasResource struct {
 
 // AliasResourceModel describes the resource data model.
 type AliasResourceModel struct {
-	DomainName   types.String `tfsdk:"domain_name"`
-	LocalPart    types.String `tfsdk:"local_part"`
-	Destinations types.List   `tfsdk:"destinations"`
-	Address      types.String `tfsdk:"address"`
-	IsInternal   types.Bool   `tfsdk:"is_internal"`
+	DomainName       DomainNameValue    `tfsdk:"domain_name"`
+	LocalPart        LocalPartValue     `tfsdk:"local_part"`
+	Destinations     UnorderedListValue `tfsdk:"destinations"`
+	Address          EmailAddressValue  `tfsdk:"address"`
+	IsInternal       types.Bool         `tfsdk:"is_internal"`
+	Expirable        types.Bool         `tfsdk:"expirable"`
+	ExpiresOn        types.String       `tfsdk:"expires_on"`
+	RemoveUponExpiry types.Bool         `tfsdk:"remove_upon_expiry"`
+	AdoptExisting    types.Bool         `tfsdk:"adopt_existing"`
+	Timeouts         timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// AliasResourceIdentityModel describes the resource identity data model.
+type AliasResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	LocalPart  types.String `tfsdk:"local_part"`
 }
 
 func (r *AliasResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,36 +65,116 @@ func (r *AliasResource) Schema(ctx context.Context, req resource.SchemaRequest,
 
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
-				MarkdownDescription: "The domain name for this alias.",
-				Required:            true,
+				MarkdownDescription: "The domain name for this alias. Required unless `address` is set.",
+				CustomType:          DomainNameType{},
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					addressPart("domain_name"),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"local_part": schema.StringAttribute{
-				MarkdownDescription: "The local part of the email address (before the @).",
-				Required:            true,
+				MarkdownDescription: "The local part of the email address (before the @). Required unless `address` is set.",
+				CustomType:          LocalPartType{},
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					addressPart("local_part"),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"destinations": schema.ListAttribute{
 				MarkdownDescription: "List of destination email addresses for this alias. All destinations must be on the same domain as the alias.",
 				Required:            true,
-				ElementType:         types.StringType,
+				ElementType:         EmailAddressType{},
+				CustomType:          NewUnorderedListType(EmailAddressType{}),
 			},
 			"address": schema.StringAttribute{
-				MarkdownDescription: "Full email address (computed).",
+				MarkdownDescription: "Full email address of the alias, known during plan. Can be set instead of `domain_name` and `local_part`, which are then derived from it in lowercase.",
+				Optional:            true,
 				Computed:            true,
+				CustomType:          EmailAddressType{},
+				PlanModifiers: []planmodifier.String{
+					addressFromParts(),
+				},
 			},
 			"is_internal": schema.BoolAttribute{
-				MarkdownDescription: "Whether this is an internal alias (computed).",
+				MarkdownDescription: "Whether the alias only accepts mail from senders on the same domain, such as for internal distribution lists. " +
+					"When unset, the value in Migadu is kept.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expirable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the alias expires on `expires_on`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "Date in `YYYY-MM-DD` format on which the alias expires. Required when `expirable` is `true`.",
+				Optional:            true,
 				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					isDate(),
+				},
+			},
+			"remove_upon_expiry": schema.BoolAttribute{
+				MarkdownDescription: "Whether Migadu deletes the alias once it expires, instead of only deactivating it. " +
+					"The resource is then removed from state on the next refresh. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over an existing alias when creating this resource finds one already there, instead of failing. " +
+					"The existing alias is updated to match this configuration. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *AliasResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the alias.",
+				RequiredForImport: true,
+			},
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the alias address (before the @).",
+				RequiredForImport: true,
 			},
 		},
 	}
 }
 
+func (r *AliasResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromMetio("migadu_alias", metioAliasSchema(), []string{"domain_name", "local_part"}, moveAliasFromMetio),
+	}
+}
+
+func (r *AliasResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return append(addressConfigValidators(), expiryConfigValidator{})
+}
+
 func (r *AliasResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -106,7 +205,16 @@ func (r *AliasResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Convert destinations from types.List to []string
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Convert destinations to []string
 	var destinations []string
 	resp.Diagnostics.Append(data.Destinations.ElementsAs(ctx, &destinations, false)...)
 	if resp.Diagnostics.HasError() {
@@ -115,24 +223,51 @@ func (r *AliasResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Create API request body
 	alias := &migadu.Alias{
-		LocalPart:    data.LocalPart.ValueString(),
-		Destinations: destinations,
+		LocalPart:        data.LocalPart.ValueString(),
+		Destinations:     addressesToASCII(destinations),
+		IsInternal:       data.IsInternal.ValueBool(),
+		Expireable:       data.Expirable.ValueBool(),
+		ExpiresOn:        data.ExpiresOn.ValueString(),
+		RemoveUponExpiry: data.RemoveUponExpiry.ValueBool(),
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 
-	// Create the alias
-	created, err := r.client.NewAlias(ctx, domain, alias)
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	// Create the alias, or adopt an existing one
+	var existing *migadu.Alias
+	created, err := createOrAdopt(ctx, data.AdoptExisting.ValueBool(), alias.LocalPart+"@"+domain.Name,
+		func() (*migadu.Alias, error) { return r.client.NewAlias(ctx, domain, alias) },
+		func() (*migadu.Alias, error) {
+			got, err := r.client.GetAlias(ctx, domain, alias)
+			existing = got
+			return got, err
+		},
+		func() (*migadu.Alias, error) {
+			// An adopted alias keeps is_internal unless it is configured.
+			adopted := *alias
+			if data.IsInternal.IsUnknown() {
+				adopted.IsInternal = existing.IsInternal
+			}
+			return r.client.UpdateAlias(ctx, domain, &adopted)
+		},
+	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create alias, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "create alias", err)
 		return
 	}
 
 	// Update the state with the created alias data
-	data.Address = types.StringValue(created.Address)
+	data.Address = appliedAddress(&resp.Diagnostics, data.Address, created.Address)
 	data.IsInternal = types.BoolValue(created.IsInternal)
 
 	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AliasResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -146,18 +281,37 @@ func (r *AliasResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AliasResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	localPart := data.LocalPart.ValueString()
 
 	// Get current state from API
 	alias, err := r.client.GetAlias(ctx, domain, &migadu.Alias{LocalPart: localPart})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read alias, got error: %s", err))
+		// The alias is gone, for example removed by Migadu upon expiry.
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read alias", err)
 		return
 	}
 
-	// Convert destinations to types.List
-	destinations, diags := types.ListValueFrom(ctx, types.StringType, alias.Destinations)
+	// Convert destinations to a list
+	destinations, diags := NewUnorderedListValueFrom(ctx, EmailAddressType{}, alias.Destinations)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -165,8 +319,16 @@ func (r *AliasResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	// Update state with API data
 	data.Destinations = destinations
-	data.Address = types.StringValue(alias.Address)
+	data.Address = stateAddress(data.Address, alias.Address)
 	data.IsInternal = types.BoolValue(alias.IsInternal)
+	data.Expirable = types.BoolValue(alias.Expireable)
+	data.ExpiresOn = types.StringValue(alias.ExpiresOn)
+	data.RemoveUponExpiry = types.BoolValue(alias.RemoveUponExpiry)
+
+	// adopt_existing only affects create; imported resources take the default.
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -182,7 +344,16 @@ func (r *AliasResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Convert destinations from types.List to []string
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Convert destinations to []string
 	var destinations []string
 	resp.Diagnostics.Append(data.Destinations.ElementsAs(ctx, &destinations, false)...)
 	if resp.Diagnostics.HasError() {
@@ -191,24 +362,35 @@ func (r *AliasResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Create API request body
 	alias := &migadu.Alias{
-		LocalPart:    data.LocalPart.ValueString(),
-		Destinations: destinations,
+		LocalPart:        data.LocalPart.ValueString(),
+		Destinations:     addressesToASCII(destinations),
+		IsInternal:       data.IsInternal.ValueBool(),
+		Expireable:       data.Expirable.ValueBool(),
+		ExpiresOn:        data.ExpiresOn.ValueString(),
+		RemoveUponExpiry: data.RemoveUponExpiry.ValueBool(),
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
 
 	// Update the alias
 	updated, err := r.client.UpdateAlias(ctx, domain, alias)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update alias, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "update alias", err)
 		return
 	}
 
 	// Update the state with the updated alias data
-	data.Address = types.StringValue(updated.Address)
+	data.Address = appliedAddress(&resp.Diagnostics, data.Address, updated.Address)
 	data.IsInternal = types.BoolValue(updated.IsInternal)
 
 	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AliasResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -222,30 +404,39 @@ func (r *AliasResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	alias := &migadu.Alias{
 		LocalPart: data.LocalPart.ValueString(),
 	}
 
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
 	// Delete the alias
 	err := r.client.DeleteAlias(ctx, domain, alias)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete alias, got error: %s", err))
+	// The alias may already be gone, such as when Migadu removed it upon
+	// expiry.
+	if err != nil && !isNotFoundError(err) {
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "delete alias", err)
 		return
 	}
 }
 
 func (r *AliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Format: domain_name/local_part
-	parts := strings.Split(req.ID, "/")
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Import ID must be in format: domain_name/local_part",
-		)
+	// Format: domain_name/local_part or the email address
+	if strings.Contains(req.ID, "@") {
+		importStateByAddress(ctx, req.ID, resp)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("local_part"), parts[1])...)
+	importStateByKey(ctx, req, resp, "domain_name", "local_part")
 }
@@ -1,41 +1,311 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/MrLemur/migadu-go"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccAliasResource_basic(t *testing.T) {
-	if os.Getenv("TF_ACC") == "" {
-		t.Skip("set TF_ACC=1 to run acceptance tests")
-	}
+	domainName := testAccSetup(t)
 
 	resourceName := "migadu_alias.test"
 	destMailboxLocalPart := fmt.Sprintf("tfacc-dest-%d", time.Now().UnixNano())
 	aliasLocalPart := fmt.Sprintf("tfacc-alias-%d", time.Now().UnixNano())
-	importID := fmt.Sprintf("%s/%s", os.Getenv("MIGADU_TEST_DOMAIN"), aliasLocalPart)
+	importID := fmt.Sprintf("%s/%s", domainName, aliasLocalPart)
 
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAliasConfig(destMailboxLocalPart, aliasLocalPart),
+				Config: testAccAliasConfig(domainName, destMailboxLocalPart, aliasLocalPart),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "domain_name", os.Getenv("MIGADU_TEST_DOMAIN")),
+					resource.TestCheckResourceAttr(resourceName, "domain_name", domainName),
 					resource.TestCheckResourceAttr(resourceName, "local_part", aliasLocalPart),
-					resource.TestCheckResourceAttr(resourceName, "destinations.0", fmt.Sprintf("%s@%s", destMailboxLocalPart, os.Getenv("MIGADU_TEST_DOMAIN"))),
+					resource.TestCheckResourceAttr(resourceName, "destinations.0", fmt.Sprintf("%s@%s", destMailboxLocalPart, domainName)),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        importID,
+				ImportStateVerifyIdentifierAttribute: "address",
+			},
+		},
+	})
+}
+
+func TestAccAliasResource_identity(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resourceName := "migadu_alias.test"
+	destMailboxLocalPart := fmt.Sprintf("tfacc-dest-%d", time.Now().UnixNano())
+	aliasLocalPart := fmt.Sprintf("tfacc-alias-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAliasConfig(domainName, destMailboxLocalPart, aliasLocalPart),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resourceName, map[string]knownvalue.Check{
+						"domain_name": knownvalue.StringExact(domainName),
+						"local_part":  knownvalue.StringExact(aliasLocalPart),
+					}),
+				},
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccAliasResource_unorderedDestinations(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_alias.test"
+
+	config := func(destinations string) string {
+		return fmt.Sprintf(`
+resource "migadu_alias" "test" {
+  domain_name  = "%s"
+  local_part   = "tfacc-alias-unordered"
+  destinations = %s
+}
+`, testFakeDomain, destinations)
+	}
+
+	// The fake API sorts and de-duplicates destinations, like Migadu does.
+	// Neither should show up as a diff.
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf(`["zed@%[1]s", "amy@%[1]s", "zed@%[1]s"]`, testFakeDomain)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "destinations.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "destinations.0", "zed@"+testFakeDomain),
+				),
+			},
+			{
+				Config:   config(fmt.Sprintf(`["zed@%[1]s", "amy@%[1]s", "zed@%[1]s"]`, testFakeDomain)),
+				PlanOnly: true,
+			},
+			{
+				Config: config(fmt.Sprintf(`["bob@%[1]s", "amy@%[1]s"]`, testFakeDomain)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "destinations.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "destinations.0", "bob@"+testFakeDomain),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAliasResource_caseInsensitive(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_alias.test"
+
+	config := func(domainName, destination string) string {
+		return fmt.Sprintf(`
+resource "migadu_alias" "test" {
+  domain_name  = "%s"
+  local_part   = "tfacc-alias-case"
+  destinations = ["%s"]
+}
+`, domainName, destination)
+	}
+
+	// The fake API lowercases destinations, like Migadu does. Reading them
+	// back in lowercase is not a diff.
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(testFakeDomain, "Amy@"+strings.ToUpper(testFakeDomain)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "destinations.0", "Amy@"+strings.ToUpper(testFakeDomain)),
+				),
+			},
+			{
+				Config:   config(testFakeDomain, "Amy@"+strings.ToUpper(testFakeDomain)),
+				PlanOnly: true,
+			},
+			{
+				Config:      config("example", "amy@"+testFakeDomain),
+				ExpectError: regexp.MustCompile(`"example" is not a valid domain name`),
+			},
+			{
+				Config:      config(testFakeDomain, "amy smith@"+testFakeDomain),
+				ExpectError: regexp.MustCompile(`is not a valid email address`),
+			},
+		},
+	})
+}
+
+func TestAccAliasResource_adoptExisting(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_alias.test"
+
+	_, err := testAccClient(t).NewAlias(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Alias{
+		LocalPart:    "tfacc-alias-adopt",
+		Destinations: []string{"old@" + testFakeDomain},
+		IsInternal:   true,
+	})
+	if err != nil {
+		t.Fatalf("failed creating alias out of band: %s", err)
+	}
+
+	config := func(localPart string) string {
+		return fmt.Sprintf(`
+resource "migadu_alias" "test" {
+  domain_name    = "%[1]s"
+  local_part     = "%[2]s"
+  destinations   = ["new@%[1]s"]
+  adopt_existing = true
+}
+`, testFakeDomain, localPart)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// An address held by a mailbox can't be adopted as an alias.
+				PreConfig: func() {
+					_, err := testAccClient(t).NewMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{
+						LocalPart:      "tfacc-mailbox-taken",
+						PasswordMethod: "password",
+						Password:       "created-by-hand",
+					})
+					if err != nil {
+						t.Fatalf("failed creating mailbox out of band: %s", err)
+					}
+				},
+				Config:      config("tfacc-mailbox-taken"),
+				ExpectError: regexp.MustCompile(`Migadu Object Already Exists`),
+			},
+			{
+				Config: config("tfacc-alias-adopt"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "destinations.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "destinations.0", "new@"+testFakeDomain),
+					// is_internal is not configured, so the adopted alias keeps it.
+					resource.TestCheckResourceAttr(resourceName, "is_internal", "true"),
 				),
 			},
+		},
+	})
+}
+
+func TestAccAliasResource_expiry(t *testing.T) {
+	server := testFakeSetup(t)
+	resourceName := "migadu_alias.test"
+
+	config := func(expiresOn string) string {
+		return fmt.Sprintf(`
+resource "migadu_alias" "test" {
+  domain_name        = "%[1]s"
+  local_part         = "tfacc-alias-expiry"
+  destinations       = ["amy@%[1]s"]
+  expirable          = true
+  expires_on         = "%[2]s"
+  remove_upon_expiry = true
+}
+`, testFakeDomain, expiresOn)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("31/01/2030"),
+				ExpectError: regexp.MustCompile(`is not a valid date`),
+			},
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile(`Missing Expiry Date`),
+			},
+			{
+				Config: config("2030-01-31"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "expirable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "expires_on", "2030-01-31"),
+					resource.TestCheckResourceAttr(resourceName, "remove_upon_expiry", "true"),
+				),
+			},
+			{
+				// Once Migadu removes the expired alias, refreshing drops it
+				// from state and the plan recreates it.
+				PreConfig: func() {
+					server.RemoveExpired("2030-01-31")
+				},
+				Config:             config("2030-01-31"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAliasResource_isInternal(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_alias.test"
+
+	config := func(isInternal bool) string {
+		return fmt.Sprintf(`
+resource "migadu_alias" "test" {
+  domain_name  = "%[1]s"
+  local_part   = "all-staff"
+  destinations = ["amy@%[1]s", "bob@%[1]s"]
+  is_internal  = %[2]t
+}
+`, testFakeDomain, isInternal)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr(resourceName, "is_internal", "true"),
+			},
+			{
+				// Removing is_internal from the configuration keeps the value
+				// in Migadu.
+				Config: fmt.Sprintf(`
+resource "migadu_alias" "test" {
+  domain_name  = "%[1]s"
+  local_part   = "all-staff"
+  destinations = ["amy@%[1]s", "bob@%[1]s"]
+}
+`, testFakeDomain),
+				PlanOnly: true,
+			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateId:     importID,
+				Config: config(false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "is_internal", "false"),
 			},
 		},
 	})
@@ -57,6 +57,36 @@ func TestAliasResourceImportState(t *testing.T) {
 		}
 	})
 
+	t.Run("address", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "Admin@Example.com"}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected import errors: %v", resp.Diagnostics)
+		}
+
+		if got := getStateStringAttribute(t, resp.State, "domain_name"); got != "example.com" {
+			t.Fatalf("expected domain_name to be %q, got %q", "example.com", got)
+		}
+
+		if got := getStateStringAttribute(t, resp.State, "local_part"); got != "admin" {
+			t.Fatalf("expected local_part to be %q, got %q", "admin", got)
+		}
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "admin@"}, &resp)
+
+		assertHasDiagnosticSummary(t, resp.Diagnostics, "Invalid Import ID")
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		resp := resource.ImportStateResponse{
 			State: newStateForSchema(schemaResp.Schema),
@@ -22,15 +22,19 @@ type AliasesDataSource struct {
 }
 
 type AliasesDataSourceModel struct {
-	DomainName types.String `tfsdk:"domain_name"`
-	Aliases    types.List   `tfsdk:"aliases"`
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	IsInternal types.Bool      `tfsdk:"is_internal"`
+	Aliases    types.List      `tfsdk:"aliases"`
 }
 
 type AliasListItemModel struct {
-	LocalPart    types.String `tfsdk:"local_part"`
-	Address      types.String `tfsdk:"address"`
-	Destinations types.List   `tfsdk:"destinations"`
-	IsInternal   types.Bool   `tfsdk:"is_internal"`
+	LocalPart        types.String `tfsdk:"local_part"`
+	Address          types.String `tfsdk:"address"`
+	Destinations     types.List   `tfsdk:"destinations"`
+	IsInternal       types.Bool   `tfsdk:"is_internal"`
+	Expirable        types.Bool   `tfsdk:"expirable"`
+	ExpiresOn        types.String `tfsdk:"expires_on"`
+	RemoveUponExpiry types.Bool   `tfsdk:"remove_upon_expiry"`
 }
 
 func (d *AliasesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -43,8 +47,13 @@ func (d *AliasesDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
+			"is_internal": schema.BoolAttribute{
+				MarkdownDescription: "If set, only aliases whose `is_internal` matches are returned.",
+				Optional:            true,
+			},
 			"aliases": schema.ListNestedAttribute{
 				MarkdownDescription: "List of aliases for this domain.",
 				Computed:            true,
@@ -60,13 +69,25 @@ func (d *AliasesDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 						},
 						"destinations": schema.ListAttribute{
 							MarkdownDescription: "List of destination email addresses.",
-							ElementType:         types.StringType,
+							ElementType:         EmailAddressType{},
 							Computed:            true,
 						},
 						"is_internal": schema.BoolAttribute{
 							MarkdownDescription: "Whether this is an internal alias.",
 							Computed:            true,
 						},
+						"expirable": schema.BoolAttribute{
+							MarkdownDescription: "Whether the alias expires.",
+							Computed:            true,
+						},
+						"expires_on": schema.StringAttribute{
+							MarkdownDescription: "Date on which the alias expires.",
+							Computed:            true,
+						},
+						"remove_upon_expiry": schema.BoolAttribute{
+							MarkdownDescription: "Whether the alias is removed once it expires.",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -98,35 +119,45 @@ func (d *AliasesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	aliases, err := d.client.ListAliases(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list aliases, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "list aliases", err)
 		return
 	}
 
 	items := make([]AliasListItemModel, 0, len(aliases))
 	for _, alias := range aliases {
-		destinations, diags := types.ListValueFrom(ctx, types.StringType, alias.Destinations)
+		if !data.IsInternal.IsNull() && alias.IsInternal != data.IsInternal.ValueBool() {
+			continue
+		}
+
+		destinations, diags := types.ListValueFrom(ctx, EmailAddressType{}, normalizeStringSlice(alias.Destinations))
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 
 		items = append(items, AliasListItemModel{
-			LocalPart:    types.StringValue(alias.LocalPart),
-			Address:      types.StringValue(alias.Address),
-			Destinations: destinations,
-			IsInternal:   types.BoolValue(alias.IsInternal),
+			LocalPart:        types.StringValue(alias.LocalPart),
+			Address:          types.StringValue(alias.Address),
+			Destinations:     destinations,
+			IsInternal:       types.BoolValue(alias.IsInternal),
+			Expirable:        types.BoolValue(alias.Expireable),
+			ExpiresOn:        types.StringValue(alias.ExpiresOn),
+			RemoveUponExpiry: types.BoolValue(alias.RemoveUponExpiry),
 		})
 	}
 
 	aliasesList, diags := types.ListValueFrom(ctx, types.ObjectType{
 		AttrTypes: map[string]attr.Type{
-			"local_part":   types.StringType,
-			"address":      types.StringType,
-			"destinations": types.ListType{ElemType: types.StringType},
-			"is_internal":  types.BoolType,
+			"local_part":         types.StringType,
+			"address":            types.StringType,
+			"destinations":       types.ListType{ElemType: EmailAddressType{}},
+			"is_internal":        types.BoolType,
+			"expirable":          types.BoolType,
+			"expires_on":         types.StringType,
+			"remove_upon_expiry": types.BoolType,
 		},
 	}, items)
 	resp.Diagnostics.Append(diags...)
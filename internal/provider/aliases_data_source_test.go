@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewAliasesDataSourceMetadata(t *testing.T) {
@@ -51,3 +54,73 @@ func TestNewAliasesDataSourceSchemaExpectations(t *testing.T) {
 		t.Fatal("expected aliases.is_internal to be a BoolAttribute")
 	}
 }
+
+func TestAccAliasesDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	suffix := time.Now().UnixNano()
+	mailboxLocalPart := fmt.Sprintf("tfacc-alias-dest-%d", suffix)
+	aliasLocalPart := fmt.Sprintf("tfacc-alias-ds-%d", suffix)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAliasConfig(domainName, mailboxLocalPart, aliasLocalPart) + `
+data "migadu_aliases" "test" {
+  domain_name = migadu_alias.test.domain_name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.migadu_aliases.test", "aliases.*", map[string]string{
+						"local_part": aliasLocalPart,
+						"address":    aliasLocalPart + "@" + domainName,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAliasesDataSource_isInternal(t *testing.T) {
+	testFakeSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "migadu_alias" "internal" {
+  domain_name  = "%[1]s"
+  local_part   = "all-staff"
+  destinations = ["amy@%[1]s"]
+  is_internal  = true
+}
+
+resource "migadu_alias" "public" {
+  domain_name  = "%[1]s"
+  local_part   = "sales"
+  destinations = ["amy@%[1]s"]
+}
+
+data "migadu_aliases" "internal" {
+  domain_name = "%[1]s"
+  is_internal = true
+  depends_on  = [migadu_alias.internal, migadu_alias.public]
+}
+
+data "migadu_aliases" "public" {
+  domain_name = "%[1]s"
+  is_internal = false
+  depends_on  = [migadu_alias.internal, migadu_alias.public]
+}
+`, testFakeDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_aliases.internal", "aliases.#", "1"),
+					resource.TestCheckResourceAttr("data.migadu_aliases.internal", "aliases.0.local_part", "all-staff"),
+					resource.TestCheckResourceAttr("data.migadu_aliases.public", "aliases.#", "1"),
+					resource.TestCheckResourceAttr("data.migadu_aliases.public", "aliases.0.local_part", "sales"),
+				),
+			},
+		},
+	})
+}
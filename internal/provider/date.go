@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// dateLayout is the format of calendar dates in the Migadu API, such as the
+// expiry date of a forwarding.
+const dateLayout = "2006-01-02"
+
+var _ validator.String = dateValidator{}
+var _ resource.ConfigValidator = expiryConfigValidator{}
+
+// isDate returns a validator requiring a calendar date in YYYY-MM-DD format.
+// The empty string, which Migadu uses for no date, is allowed.
+func isDate() validator.String {
+	return dateValidator{}
+}
+
+type dateValidator struct{}
+
+func (v dateValidator) Description(ctx context.Context) string {
+	return "value must be a date in YYYY-MM-DD format"
+}
+
+func (v dateValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a date in `YYYY-MM-DD` format"
+}
+
+func (v dateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if value == "" {
+		return
+	}
+
+	if _, err := time.Parse(dateLayout, value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Date",
+			fmt.Sprintf("%q is not a valid date in YYYY-MM-DD format.", value),
+		)
+	}
+}
+
+// expiryConfigValidator requires expires_on to be set to a date when
+// expirable is true, since Migadu can't expire a resource without one.
+type expiryConfigValidator struct{}
+
+func (v expiryConfigValidator) Description(ctx context.Context) string {
+	return "expires_on must be set when expirable is true"
+}
+
+func (v expiryConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return "`expires_on` must be set when `expirable` is `true`"
+}
+
+func (v expiryConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var expirable types.Bool
+	var expiresOn types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expirable"), &expirable)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expires_on"), &expiresOn)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !expirable.ValueBool() || expiresOn.IsUnknown() || expiresOn.ValueString() != "" {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("expires_on"),
+		"Missing Expiry Date",
+		"expires_on must be set to a date in YYYY-MM-DD format when expirable is true.",
+	)
+}
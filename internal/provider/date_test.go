@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDateValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"date":         {value: types.StringValue("2030-12-31")},
+		"empty":        {value: types.StringValue("")},
+		"null":         {value: types.StringNull()},
+		"unknown":      {value: types.StringUnknown()},
+		"timestamp":    {value: types.StringValue("2030-12-31T00:00:00Z"), expectErr: true},
+		"invalid date": {value: types.StringValue("2030-02-30"), expectErr: true},
+		"other format": {value: types.StringValue("31.12.2030"), expectErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("expires_on"),
+				ConfigValue: tc.value,
+			}
+			var resp validator.StringResponse
+
+			isDate().ValidateString(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.expectErr {
+				t.Fatalf("expected error %t, got %v", tc.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
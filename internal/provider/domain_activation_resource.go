@@ -4,18 +4,23 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/MrLemur/migadu-go"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &DomainActivationResource{}
 var _ resource.ResourceWithImportState = &DomainActivationResource{}
+var _ resource.ResourceWithIdentity = &DomainActivationResource{}
 
 func NewDomainActivationResource() resource.Resource {
 	return &DomainActivationResource{}
@@ -25,9 +30,24 @@ type DomainActivationResource struct {
 	client *migadu.Client
 }
 
+// dnsPollInterval is how often diagnostics are checked when wait_for_dns is
+// enabled.
+var dnsPollInterval = 30 * time.Second
+
+// defaultDNSWaitTimeout replaces defaultCreateTimeout when wait_for_dns is
+// enabled, to allow for DNS propagation.
+const defaultDNSWaitTimeout = 30 * time.Minute
+
 type DomainActivationResourceModel struct {
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	State      types.String    `tfsdk:"state"`
+	WaitForDNS types.Bool      `tfsdk:"wait_for_dns"`
+	Timeouts   timeouts.Value  `tfsdk:"timeouts"`
+}
+
+// DomainActivationResourceIdentityModel describes the resource identity data model.
+type DomainActivationResourceIdentityModel struct {
 	DomainName types.String `tfsdk:"domain_name"`
-	State      types.String `tfsdk:"state"`
 }
 
 func (r *DomainActivationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -37,12 +57,13 @@ func (r *DomainActivationResource) Metadata(ctx context.Context, req resource.Me
 func (r *DomainActivationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Activates a Migadu domain once DNS records are in place.\n\n" +
-			"~> **Note:** DNS records (MX, SPF, DKIM, DMARC) must be valid before this resource will apply successfully.\n\n" +
+			"~> **Note:** DNS records (MX, SPF, DKIM, DMARC) must be valid before this resource will apply successfully, unless `wait_for_dns` is set.\n\n" +
 			"-> **Note:** Destroying this resource does not deactivate the domain.",
 
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name to activate.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -51,6 +72,34 @@ func (r *DomainActivationResource) Schema(ctx context.Context, req resource.Sche
 			"state": schema.StringAttribute{
 				MarkdownDescription: "Domain state after activation.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"wait_for_dns": schema.BoolAttribute{
+				MarkdownDescription: "Keep polling the domain diagnostics until the DNS records validate instead of failing immediately. " +
+					"Waits up to the create timeout, which defaults to 30 minutes when this is enabled. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+			}),
+		},
+	}
+}
+
+func (r *DomainActivationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name to activate.",
+				RequiredForImport: true,
 			},
 		},
 	}
@@ -80,27 +129,55 @@ func (r *DomainActivationResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	name := data.DomainName.ValueString()
+	defaultTimeout := defaultCreateTimeout
+	if data.WaitForDNS.ValueBool() {
+		defaultTimeout = defaultDNSWaitTimeout
+	}
 
-	diag, err := r.client.GetDomainDiagnostics(ctx, &migadu.Domain{Name: name})
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get domain diagnostics, got error: %s", err))
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var issues []string
-	if len(diag.MX) > 0 {
-		issues = append(issues, fmt.Sprintf("MX: %s", strings.Join(diag.MX, "; ")))
-	}
-	if len(diag.SPF) > 0 {
-		issues = append(issues, fmt.Sprintf("SPF: %s", strings.Join(diag.SPF, "; ")))
-	}
-	if len(diag.DKIM) > 0 {
-		issues = append(issues, fmt.Sprintf("DKIM: %s", strings.Join(diag.DKIM, "; ")))
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	name := data.DomainName.ValueASCII()
+
+	issues, err := r.dnsIssues(ctx, name)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "get domain diagnostics", err)
+		return
 	}
-	if len(diag.DMARC) > 0 {
-		issues = append(issues, fmt.Sprintf("DMARC: %s", strings.Join(diag.DMARC, "; ")))
+
+	for len(issues) > 0 && data.WaitForDNS.ValueBool() {
+		tflog.Info(ctx, "Waiting for domain DNS records to validate", map[string]any{
+			"domain_name": name,
+			"issues":      issues,
+		})
+
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(
+				"DNS Validation Timed Out",
+				fmt.Sprintf("Timed out after %s waiting for the DNS records of %s to validate. Last reported issues:\n%s", createTimeout, name, strings.Join(issues, "\n")),
+			)
+			return
+		case <-time.After(dnsPollInterval):
+		}
+
+		latest, err := r.dnsIssues(ctx, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "get domain diagnostics", err)
+			return
+		}
+		issues = latest
 	}
+
 	if len(issues) > 0 {
 		resp.Diagnostics.AddError(
 			"DNS Validation Failed",
@@ -109,17 +186,47 @@ func (r *DomainActivationResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	domainLocks.Lock(name)
+	defer domainLocks.Unlock(name)
+
 	domain, err := r.client.ActivateDomain(ctx, &migadu.Domain{Name: name})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to activate domain, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "activate domain", err)
 		return
 	}
 
 	data.State = types.StringValue(domain.State)
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainActivationResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// dnsIssues returns the outstanding DNS problems Migadu reports for a domain,
+// one line per record type, or nil once all records are valid.
+func (r *DomainActivationResource) dnsIssues(ctx context.Context, name string) ([]string, error) {
+	diag, err := r.client.GetDomainDiagnostics(ctx, &migadu.Domain{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []string
+	if len(diag.MX) > 0 {
+		issues = append(issues, fmt.Sprintf("MX: %s", strings.Join(diag.MX, "; ")))
+	}
+	if len(diag.SPF) > 0 {
+		issues = append(issues, fmt.Sprintf("SPF: %s", strings.Join(diag.SPF, "; ")))
+	}
+	if len(diag.DKIM) > 0 {
+		issues = append(issues, fmt.Sprintf("DKIM: %s", strings.Join(diag.DKIM, "; ")))
+	}
+	if len(diag.DMARC) > 0 {
+		issues = append(issues, fmt.Sprintf("DMARC: %s", strings.Join(diag.DMARC, "; ")))
+	}
+	return issues, nil
+}
+
 func (r *DomainActivationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data DomainActivationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -127,19 +234,53 @@ func (r *DomainActivationResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	domain, err := r.client.GetDomain(ctx, &migadu.Domain{Name: data.DomainName.ValueString()})
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainActivationResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain, err := r.client.GetDomain(ctx, &migadu.Domain{Name: data.DomainName.ValueASCII()})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read domain", err)
 		return
 	}
 
 	data.State = types.StringValue(domain.State)
 
+	// wait_for_dns only affects create; imported activations take the default.
+	if data.WaitForDNS.IsNull() {
+		data.WaitForDNS = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DomainActivationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// domain_name is RequiresReplace and state is Computed — no update path needed
+	// domain_name is RequiresReplace and state is kept from the prior state,
+	// so only wait_for_dns and timeouts can change here and there is nothing
+	// to send to the API.
+	var data DomainActivationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainActivationResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+	})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DomainActivationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -147,5 +288,5 @@ func (r *DomainActivationResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *DomainActivationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+	importStateByKey(ctx, req, resp, "domain_name")
 }
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &DomainCatchallResource{}
+var _ resource.ResourceWithImportState = &DomainCatchallResource{}
+var _ resource.ResourceWithIdentity = &DomainCatchallResource{}
+
+func NewDomainCatchallResource() resource.Resource {
+	return &DomainCatchallResource{}
+}
+
+type DomainCatchallResource struct {
+	client *migadu.Client
+}
+
+type DomainCatchallResourceModel struct {
+	DomainName   DomainNameValue    `tfsdk:"domain_name"`
+	Destinations UnorderedListValue `tfsdk:"destinations"`
+	Timeouts     timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// DomainCatchallResourceIdentityModel describes the resource identity data
+// model.
+type DomainCatchallResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+}
+
+func (r *DomainCatchallResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_catchall"
+}
+
+func (r *DomainCatchallResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the catch-all destinations of an existing Migadu domain, separately from the domain itself. " +
+			"Leave `catchall_destinations` unset on the `migadu_domain` resource when using this resource.\n\n" +
+			"-> **Note:** Destroying this resource clears the catch-all destinations. It does not delete the domain.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"destinations": schema.ListAttribute{
+				MarkdownDescription: "Email addresses that receive mail sent to addresses of the domain that do not exist.",
+				Required:            true,
+				ElementType:         EmailAddressType{},
+				CustomType:          NewUnorderedListType(EmailAddressType{}),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *DomainCatchallResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *DomainCatchallResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*migadu.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *migadu.Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DomainCatchallResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DomainCatchallResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var destinations []string
+	resp.Diagnostics.Append(data.Destinations.ElementsAs(ctx, &destinations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setDestinations(ctx, data, addressesToASCII(destinations)); err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "create domain catch-all", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainCatchallResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+	})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainCatchallResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DomainCatchallResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainCatchallResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	retrieved, err := r.client.GetDomain(ctx, &migadu.Domain{Name: data.DomainName.ValueASCII()})
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read domain", err)
+		return
+	}
+
+	destinations, diags := NewUnorderedListValueFrom(ctx, EmailAddressType{}, retrieved.CatchallDestinations)
+	resp.Diagnostics.Append(diags...)
+	data.Destinations = destinations
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainCatchallResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DomainCatchallResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var destinations []string
+	resp.Diagnostics.Append(data.Destinations.ElementsAs(ctx, &destinations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setDestinations(ctx, data, addressesToASCII(destinations)); err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "update domain catch-all", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainCatchallResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+	})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainCatchallResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DomainCatchallResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.setDestinations(ctx, data, []string{})
+	if err != nil && !isNotFoundError(err) {
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "delete domain catch-all", err)
+		return
+	}
+}
+
+func (r *DomainCatchallResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByKey(ctx, req, resp, "domain_name")
+}
+
+// setDestinations replaces the catch-all destinations of the domain. The
+// Migadu API only updates whole domains, so the domain is read first to send
+// its other settings back unchanged.
+func (r *DomainCatchallResource) setDestinations(ctx context.Context, data DomainCatchallResourceModel, destinations []string) error {
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	current, err := r.client.GetDomain(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	current.CatchallDestinations = destinations
+	_, err = r.client.UpdateDomain(ctx, current)
+	return err
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDomainCatchallResource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resourceName := "migadu_domain_catchall.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainCatchallConfig(domainName, `["catchall@example.net"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "domain_name", domainName),
+					resource.TestCheckResourceAttr(resourceName, "destinations.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "destinations.0", "catchall@example.net"),
+				),
+			},
+			{
+				Config: testAccDomainCatchallConfig(domainName, `["amy@example.net", "zed@example.net"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "destinations.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "destinations.1", "zed@example.net"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        domainName,
+				ImportStateVerifyIdentifierAttribute: "domain_name",
+			},
+		},
+		CheckDestroy: testAccCheckCatchallCleared(t, domainName),
+	})
+}
+
+func TestAccDomainCatchallResource_identity(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resourceName := "migadu_domain_catchall.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainCatchallConfig(domainName, `["catchall@example.net"]`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resourceName, map[string]knownvalue.Check{
+						"domain_name": knownvalue.StringExact(domainName),
+					}),
+				},
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccDomainCatchallResource_withDomain(t *testing.T) {
+	testFakeSetup(t)
+
+	config := func(description string) string {
+		return fmt.Sprintf(`
+resource "migadu_domain" "test" {
+  name        = "tfacc.example.net"
+  description = "%s"
+}
+
+resource "migadu_domain_catchall" "test" {
+  domain_name  = migadu_domain.test.name
+  destinations = ["catchall@example.net"]
+}
+`, description)
+	}
+
+	// The domain leaves catchall_destinations unset, so updating it keeps
+	// the destinations managed by migadu_domain_catchall and neither
+	// resource plans a change afterwards.
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Terraform acceptance"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_domain_catchall.test", "destinations.0", "catchall@example.net"),
+				),
+			},
+			{
+				Config: config("Terraform acceptance updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_domain.test", "description", "Terraform acceptance updated"),
+					resource.TestCheckResourceAttr("migadu_domain.test", "catchall_destinations.0", "catchall@example.net"),
+					resource.TestCheckResourceAttr("migadu_domain_catchall.test", "destinations.0", "catchall@example.net"),
+				),
+			},
+			{
+				Config:   config("Terraform acceptance updated"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// testAccCheckCatchallCleared checks that the domain exists without
+// catch-all destinations.
+func testAccCheckCatchallCleared(t *testing.T, domainName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		domain, err := testAccClient(t).GetDomain(context.Background(), &migadu.Domain{Name: domainName})
+		if err != nil {
+			return err
+		}
+		if len(domain.CatchallDestinations) != 0 {
+			return fmt.Errorf("expected the catch-all destinations of %s to be cleared, got %v", domainName, domain.CatchallDestinations)
+		}
+		return nil
+	}
+}
+
+func testAccDomainCatchallConfig(domainName, destinations string) string {
+	return fmt.Sprintf(`
+resource "migadu_domain_catchall" "test" {
+  domain_name  = "%s"
+  destinations = %s
+}
+`, domainName, destinations)
+}
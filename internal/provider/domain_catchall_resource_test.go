@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestNewDomainCatchallResourceMetadata(t *testing.T) {
+	r := NewDomainCatchallResource()
+
+	var resp resource.MetadataResponse
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "migadu"}, &resp)
+
+	if resp.TypeName != "migadu_domain_catchall" {
+		t.Fatalf("expected type name %q, got %q", "migadu_domain_catchall", resp.TypeName)
+	}
+}
+
+func TestDomainCatchallResourceImportState(t *testing.T) {
+	r := NewDomainCatchallResource()
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		t.Fatal("expected domain catch-all resource to implement ResourceWithImportState")
+	}
+	schemaResp := mustResourceSchema(t, r)
+
+	resp := resource.ImportStateResponse{
+		State: newStateForSchema(schemaResp.Schema),
+	}
+
+	importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "example.com"}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import errors: %v", resp.Diagnostics)
+	}
+
+	if got := getStateStringAttribute(t, resp.State, "domain_name"); got != "example.com" {
+		t.Fatalf("expected domain_name to be %q, got %q", "example.com", got)
+	}
+}
@@ -20,18 +20,20 @@ type DomainDataSource struct {
 }
 
 type DomainDataSourceModel struct {
-	Name                 types.String `tfsdk:"name"`
-	State                types.String `tfsdk:"state"`
-	Description          types.String `tfsdk:"description"`
-	Tags                 types.List   `tfsdk:"tags"`
-	SpamAggressiveness   types.String  `tfsdk:"spam_aggressiveness"`
-	GreylistingEnabled   types.Bool   `tfsdk:"greylisting_enabled"`
-	MXProxyEnabled       types.Bool   `tfsdk:"mx_proxy_enabled"`
-	HostedDNS            types.Bool   `tfsdk:"hosted_dns"`
-	SenderAllowlist      types.List   `tfsdk:"sender_allowlist"`
-	SenderDenylist       types.List   `tfsdk:"sender_denylist"`
-	RecipientDenylist    types.List   `tfsdk:"recipient_denylist"`
-	CatchallDestinations types.List   `tfsdk:"catchall_destinations"`
+	Name                 DomainNameValue `tfsdk:"name"`
+	NameASCII            types.String    `tfsdk:"name_ascii"`
+	NameUnicode          types.String    `tfsdk:"name_unicode"`
+	State                types.String    `tfsdk:"state"`
+	Description          types.String    `tfsdk:"description"`
+	Tags                 types.List      `tfsdk:"tags"`
+	SpamAggressiveness   types.String    `tfsdk:"spam_aggressiveness"`
+	GreylistingEnabled   types.Bool      `tfsdk:"greylisting_enabled"`
+	MXProxyEnabled       types.Bool      `tfsdk:"mx_proxy_enabled"`
+	HostedDNS            types.Bool      `tfsdk:"hosted_dns"`
+	SenderAllowlist      types.List      `tfsdk:"sender_allowlist"`
+	SenderDenylist       types.List      `tfsdk:"sender_denylist"`
+	RecipientDenylist    types.List      `tfsdk:"recipient_denylist"`
+	CatchallDestinations types.List      `tfsdk:"catchall_destinations"`
 }
 
 func (d *DomainDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -44,9 +46,18 @@ func (d *DomainDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The domain name.",
+				MarkdownDescription: "The domain name, in Unicode or punycode form.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
+			"name_ascii": schema.StringAttribute{
+				MarkdownDescription: "The domain name in ASCII form, with internationalized labels in punycode.",
+				Computed:            true,
+			},
+			"name_unicode": schema.StringAttribute{
+				MarkdownDescription: "The domain name in Unicode form.",
+				Computed:            true,
+			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "Domain state.",
 				Computed:            true,
@@ -77,24 +88,24 @@ func (d *DomainDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:            true,
 			},
 			"sender_allowlist": schema.ListAttribute{
-				MarkdownDescription: "List of allowed sender addresses.",
+				MarkdownDescription: "List of allowed sender addresses or domains.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         AddressOrDomainType{},
 			},
 			"sender_denylist": schema.ListAttribute{
-				MarkdownDescription: "List of denied sender addresses.",
+				MarkdownDescription: "List of denied sender addresses or domains.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         AddressOrDomainType{},
 			},
 			"recipient_denylist": schema.ListAttribute{
-				MarkdownDescription: "List of denied recipient addresses.",
+				MarkdownDescription: "List of denied recipient addresses or domains.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         AddressOrDomainType{},
 			},
 			"catchall_destinations": schema.ListAttribute{
 				MarkdownDescription: "Catchall email destinations.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         EmailAddressType{},
 			},
 		},
 	}
@@ -124,37 +135,38 @@ func (d *DomainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.Name.ValueString()}
+	domain := &migadu.Domain{Name: data.Name.ValueASCII()}
 	retrieved, err := d.client.GetDomain(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "read domain", err)
 		return
 	}
 
 	data.State = types.StringValue(retrieved.State)
+	data.NameASCII, data.NameUnicode = domainNameForms(data.Name.ValueString())
 	data.Description = types.StringValue(retrieved.Description)
 	data.SpamAggressiveness = types.StringValue(retrieved.SpamAggressiveness)
 	data.GreylistingEnabled = types.BoolValue(retrieved.GreylistingEnabled)
 	data.MXProxyEnabled = types.BoolValue(retrieved.MXProxyEnabled)
 	data.HostedDNS = types.BoolValue(retrieved.HostedDNS)
 
-	tags, diags := types.ListValueFrom(ctx, types.StringType, retrieved.Tags)
+	tags, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(retrieved.Tags))
 	resp.Diagnostics.Append(diags...)
 	data.Tags = tags
 
-	senderAllowlist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(retrieved.SenderAllowlist))
+	senderAllowlist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(retrieved.SenderAllowlist))
 	resp.Diagnostics.Append(diags...)
 	data.SenderAllowlist = senderAllowlist
 
-	senderDenylist, diags := types.ListValueFrom(ctx, types.StringType, retrieved.SenderDenylist)
+	senderDenylist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(retrieved.SenderDenylist))
 	resp.Diagnostics.Append(diags...)
 	data.SenderDenylist = senderDenylist
 
-	recipientDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(retrieved.RecipientDenylist))
+	recipientDenylist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(retrieved.RecipientDenylist))
 	resp.Diagnostics.Append(diags...)
 	data.RecipientDenylist = recipientDenylist
 
-	catchallDestinations, diags := types.ListValueFrom(ctx, types.StringType, retrieved.CatchallDestinations)
+	catchallDestinations, diags := types.ListValueFrom(ctx, EmailAddressType{}, normalizeStringSlice(retrieved.CatchallDestinations))
 	resp.Diagnostics.Append(diags...)
 	data.CatchallDestinations = catchallDestinations
 
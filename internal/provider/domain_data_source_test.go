@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewDomainDataSourceMetadata(t *testing.T) {
@@ -39,3 +41,24 @@ func TestNewDomainDataSourceSchemaExpectations(t *testing.T) {
 	requireDataSourceStringAttribute(t, attrs, "description", false, true)
 	requireDataSourceListAttribute(t, attrs, "tags", false, true)
 }
+
+func TestAccDomainDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "migadu_domain" "test" {
+  name = "%s"
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_domain.test", "name", domainName),
+					resource.TestCheckResourceAttr("data.migadu_domain.test", "state", "active"),
+				),
+			},
+		},
+	})
+}
@@ -21,11 +21,11 @@ type DomainDiagnosticsDataSource struct {
 }
 
 type DomainDiagnosticsDataSourceModel struct {
-	DomainName types.String `tfsdk:"domain_name"`
-	MX         types.List   `tfsdk:"mx"`
-	SPF        types.List   `tfsdk:"spf"`
-	DKIM       types.List   `tfsdk:"dkim"`
-	DMARC      types.List   `tfsdk:"dmarc"`
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	MX         types.List      `tfsdk:"mx"`
+	SPF        types.List      `tfsdk:"spf"`
+	DKIM       types.List      `tfsdk:"dkim"`
+	DMARC      types.List      `tfsdk:"dmarc"`
 }
 
 func (d *DomainDiagnosticsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -41,6 +41,7 @@ func (d *DomainDiagnosticsDataSource) Schema(ctx context.Context, req datasource
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name to diagnose.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"mx": schema.ListAttribute{
@@ -91,10 +92,10 @@ func (d *DomainDiagnosticsDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	diagnostics, err := d.client.GetDomainDiagnostics(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get domain diagnostics, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "get domain diagnostics", err)
 		return
 	}
 
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/MrLemur/terraform-provider-migadu/internal/migadutest"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewDomainDiagnosticsDataSourceMetadata(t *testing.T) {
@@ -40,3 +43,31 @@ func TestNewDomainDiagnosticsDataSourceSchemaExpectations(t *testing.T) {
 	requireDataSourceListAttribute(t, attrs, "dkim", false, true)
 	requireDataSourceListAttribute(t, attrs, "dmarc", false, true)
 }
+
+func TestAccDomainDiagnosticsDataSource_basic(t *testing.T) {
+	server := testFakeSetup(t)
+	err := server.SetDiagnostics(testFakeDomain, migadutest.Diagnostics{
+		SPF: []string{"SPF record is missing"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "migadu_domain_diagnostics" "test" {
+  domain_name = "%s"
+}
+`, testFakeDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "mx.#", "0"),
+					resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "spf.#", "1"),
+					resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "spf.0", "SPF record is missing"),
+				),
+			},
+		},
+	})
+}
@@ -22,8 +22,8 @@ type DomainDNSRecordsDataSource struct {
 }
 
 type DomainDNSRecordsDataSourceModel struct {
-	DomainName types.String `tfsdk:"domain_name"`
-	Records    types.List   `tfsdk:"records"`
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	Records    types.List      `tfsdk:"records"`
 }
 
 type DNSRecordModel struct {
@@ -45,6 +45,7 @@ func (d *DomainDNSRecordsDataSource) Schema(ctx context.Context, req datasource.
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"records": schema.ListNestedAttribute{
@@ -103,10 +104,10 @@ func (d *DomainDNSRecordsDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	records, err := d.client.GetDomainRecords(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get domain DNS records, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "get domain DNS records", err)
 		return
 	}
 
@@ -2,10 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewDomainDNSRecordsDataSourceMetadata(t *testing.T) {
@@ -59,3 +61,26 @@ func TestNewDomainDNSRecordsDataSourceSchemaExpectations(t *testing.T) {
 		t.Fatal("expected records.ttl to be a computed Int64Attribute")
 	}
 }
+
+func TestAccDomainDNSRecordsDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "migadu_domain_dns_records" "test" {
+  domain_name = "%s"
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.migadu_domain_dns_records.test", "records.*", map[string]string{
+						"type": "MX",
+						"name": domainName,
+					}),
+				),
+			},
+		},
+	})
+}
@@ -3,24 +3,29 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 )
 
 var _ resource.Resource = &DomainResource{}
 var _ resource.ResourceWithImportState = &DomainResource{}
+var _ resource.ResourceWithIdentity = &DomainResource{}
 
 func NewDomainResource() resource.Resource {
 	return &DomainResource{}
@@ -31,18 +36,26 @@ type DomainResource struct {
 }
 
 type DomainResourceModel struct {
-	Name                 types.String `tfsdk:"name"`
-	State                types.String `tfsdk:"state"`
-	Description          types.String `tfsdk:"description"`
-	Tags                 types.List   `tfsdk:"tags"`
-	SpamAggressiveness   types.String  `tfsdk:"spam_aggressiveness"`
-	GreylistingEnabled   types.Bool   `tfsdk:"greylisting_enabled"`
-	MXProxyEnabled       types.Bool   `tfsdk:"mx_proxy_enabled"`
-	HostedDNS            types.Bool   `tfsdk:"hosted_dns"`
-	SenderAllowlist      types.List   `tfsdk:"sender_allowlist"`
-	SenderDenylist       types.List   `tfsdk:"sender_denylist"`
-	RecipientDenylist    types.List   `tfsdk:"recipient_denylist"`
-	CatchallDestinations types.List   `tfsdk:"catchall_destinations"`
+	Name                 DomainNameValue    `tfsdk:"name"`
+	NameASCII            types.String       `tfsdk:"name_ascii"`
+	NameUnicode          types.String       `tfsdk:"name_unicode"`
+	State                types.String       `tfsdk:"state"`
+	Description          types.String       `tfsdk:"description"`
+	Tags                 UnorderedListValue `tfsdk:"tags"`
+	SpamAggressiveness   types.String       `tfsdk:"spam_aggressiveness"`
+	GreylistingEnabled   types.Bool         `tfsdk:"greylisting_enabled"`
+	MXProxyEnabled       types.Bool         `tfsdk:"mx_proxy_enabled"`
+	HostedDNS            types.Bool         `tfsdk:"hosted_dns"`
+	SenderAllowlist      UnorderedListValue `tfsdk:"sender_allowlist"`
+	SenderDenylist       UnorderedListValue `tfsdk:"sender_denylist"`
+	RecipientDenylist    UnorderedListValue `tfsdk:"recipient_denylist"`
+	CatchallDestinations UnorderedListValue `tfsdk:"catchall_destinations"`
+	Timeouts             timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// DomainResourceIdentityModel describes the resource identity data model.
+type DomainResourceIdentityModel struct {
+	Name types.String `tfsdk:"name"`
 }
 
 func (r *DomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,12 +68,27 @@ func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest,
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The domain name.",
+				MarkdownDescription: "The domain name. Internationalized names can be given in Unicode or punycode form, and are kept as written.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"name_ascii": schema.StringAttribute{
+				MarkdownDescription: "The domain name in ASCII form, with internationalized labels in punycode, as used by Migadu and in DNS.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name_unicode": schema.StringAttribute{
+				MarkdownDescription: "The domain name in Unicode form.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "Domain state (computed).",
 				Computed:            true,
@@ -79,6 +107,7 @@ func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
+				CustomType:          NewUnorderedListType(types.StringType),
 				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
 			},
 			"spam_aggressiveness": schema.StringAttribute{
@@ -110,32 +139,59 @@ func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:             booldefault.StaticBool(false),
 			},
 			"sender_allowlist": schema.ListAttribute{
-				MarkdownDescription: "List of allowed sender addresses.",
+				MarkdownDescription: "List of allowed sender addresses or domains.",
 				Optional:            true,
 				Computed:            true,
-				ElementType:         types.StringType,
-				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				ElementType:         AddressOrDomainType{},
+				CustomType:          NewUnorderedListType(AddressOrDomainType{}),
+				Default:             listdefault.StaticValue(types.ListValueMust(AddressOrDomainType{}, []attr.Value{})),
 			},
 			"sender_denylist": schema.ListAttribute{
-				MarkdownDescription: "List of denied sender addresses.",
+				MarkdownDescription: "List of denied sender addresses or domains.",
 				Optional:            true,
 				Computed:            true,
-				ElementType:         types.StringType,
-				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				ElementType:         AddressOrDomainType{},
+				CustomType:          NewUnorderedListType(AddressOrDomainType{}),
+				Default:             listdefault.StaticValue(types.ListValueMust(AddressOrDomainType{}, []attr.Value{})),
 			},
 			"recipient_denylist": schema.ListAttribute{
-				MarkdownDescription: "List of denied recipient addresses.",
+				MarkdownDescription: "List of denied recipient addresses or domains.",
 				Optional:            true,
 				Computed:            true,
-				ElementType:         types.StringType,
-				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				ElementType:         AddressOrDomainType{},
+				CustomType:          NewUnorderedListType(AddressOrDomainType{}),
+				Default:             listdefault.StaticValue(types.ListValueMust(AddressOrDomainType{}, []attr.Value{})),
 			},
 			"catchall_destinations": schema.ListAttribute{
-				MarkdownDescription: "Catchall email destinations.",
-				Optional:            true,
-				Computed:            true,
-				ElementType:         types.StringType,
-				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				MarkdownDescription: "Catchall email destinations. If unset, the destinations in Migadu are left unchanged, " +
+					"so that they can be managed with the `migadu_domain_catchall` resource instead. " +
+					"Set it to `[]` to remove all destinations; removing the attribute from the configuration no longer clears them.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: EmailAddressType{},
+				CustomType:  NewUnorderedListType(EmailAddressType{}),
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *DomainResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The domain name.",
+				RequiredForImport: true,
 			},
 		},
 	}
@@ -165,38 +221,61 @@ func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var tags, senderAllowlist, senderDenylist, recipientDenylistSlice, catchallDestinations []string
 	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
 	resp.Diagnostics.Append(data.SenderAllowlist.ElementsAs(ctx, &senderAllowlist, false)...)
 	resp.Diagnostics.Append(data.SenderDenylist.ElementsAs(ctx, &senderDenylist, false)...)
 	resp.Diagnostics.Append(data.RecipientDenylist.ElementsAs(ctx, &recipientDenylistSlice, false)...)
-	resp.Diagnostics.Append(data.CatchallDestinations.ElementsAs(ctx, &catchallDestinations, false)...)
+	if !data.CatchallDestinations.IsUnknown() {
+		resp.Diagnostics.Append(data.CatchallDestinations.ElementsAs(ctx, &catchallDestinations, false)...)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	domain := &migadu.Domain{
-		Name:                 data.Name.ValueString(),
+		Name:                 data.Name.ValueASCII(),
 		Description:          data.Description.ValueString(),
 		Tags:                 tags,
 		SpamAggressiveness:   data.SpamAggressiveness.ValueString(),
 		GreylistingEnabled:   data.GreylistingEnabled.ValueBool(),
 		MXProxyEnabled:       data.MXProxyEnabled.ValueBool(),
 		HostedDNS:            data.HostedDNS.ValueBool(),
-		SenderAllowlist:      senderAllowlist,
-		SenderDenylist:       senderDenylist,
-		RecipientDenylist:    recipientDenylistSlice,
-		CatchallDestinations: catchallDestinations,
+		SenderAllowlist:      addressesToASCII(senderAllowlist),
+		SenderDenylist:       addressesToASCII(senderDenylist),
+		RecipientDenylist:    addressesToASCII(recipientDenylistSlice),
+		CatchallDestinations: addressesToASCII(catchallDestinations),
 	}
 
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
 	created, err := r.client.NewDomain(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create domain, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "create domain", err)
 		return
 	}
 
 	data.State = types.StringValue(created.State)
+	data.NameASCII, data.NameUnicode = domainNameForms(data.Name.ValueString())
+	if data.CatchallDestinations.IsUnknown() {
+		catchallDestinations, diags := NewUnorderedListValueFrom(ctx, EmailAddressType{}, created.CatchallDestinations)
+		resp.Diagnostics.Append(diags...)
+		data.CatchallDestinations = catchallDestinations
+	}
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainResourceIdentityModel{
+		Name: data.Name.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -207,37 +286,55 @@ func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.Name.ValueString()}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainResourceIdentityModel{
+		Name: data.Name.StringValue,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.Name.ValueASCII()}
 	retrieved, err := r.client.GetDomain(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read domain", err)
 		return
 	}
 
 	data.State = types.StringValue(retrieved.State)
+	data.NameASCII, data.NameUnicode = domainNameForms(data.Name.ValueString())
 	data.Description = types.StringValue(retrieved.Description)
 	data.SpamAggressiveness = types.StringValue(retrieved.SpamAggressiveness)
 	data.GreylistingEnabled = types.BoolValue(retrieved.GreylistingEnabled)
 	data.MXProxyEnabled = types.BoolValue(retrieved.MXProxyEnabled)
 	data.HostedDNS = types.BoolValue(retrieved.HostedDNS)
 
-	tags, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(retrieved.Tags))
+	tags, diags := NewUnorderedListValueFrom(ctx, types.StringType, retrieved.Tags)
 	resp.Diagnostics.Append(diags...)
 	data.Tags = tags
 
-	senderAllowlist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(retrieved.SenderAllowlist))
+	senderAllowlist, diags := NewUnorderedListValueFrom(ctx, AddressOrDomainType{}, retrieved.SenderAllowlist)
 	resp.Diagnostics.Append(diags...)
 	data.SenderAllowlist = senderAllowlist
 
-	senderDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(retrieved.SenderDenylist))
+	senderDenylist, diags := NewUnorderedListValueFrom(ctx, AddressOrDomainType{}, retrieved.SenderDenylist)
 	resp.Diagnostics.Append(diags...)
 	data.SenderDenylist = senderDenylist
 
-	recipientDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(retrieved.RecipientDenylist))
+	recipientDenylist, diags := NewUnorderedListValueFrom(ctx, AddressOrDomainType{}, retrieved.RecipientDenylist)
 	resp.Diagnostics.Append(diags...)
 	data.RecipientDenylist = recipientDenylist
 
-	catchallDestinations, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(retrieved.CatchallDestinations))
+	catchallDestinations, diags := NewUnorderedListValueFrom(ctx, EmailAddressType{}, retrieved.CatchallDestinations)
 	resp.Diagnostics.Append(diags...)
 	data.CatchallDestinations = catchallDestinations
 
@@ -251,39 +348,68 @@ func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var tags, senderAllowlist, senderDenylist, recipientDenylistSlice, catchallDestinations []string
 	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
 	resp.Diagnostics.Append(data.SenderAllowlist.ElementsAs(ctx, &senderAllowlist, false)...)
 	resp.Diagnostics.Append(data.SenderDenylist.ElementsAs(ctx, &senderDenylist, false)...)
 	resp.Diagnostics.Append(data.RecipientDenylist.ElementsAs(ctx, &recipientDenylistSlice, false)...)
 	resp.Diagnostics.Append(data.CatchallDestinations.ElementsAs(ctx, &catchallDestinations, false)...)
+
+	var configuredCatchall UnorderedListValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("catchall_destinations"), &configuredCatchall)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	domain := &migadu.Domain{
-		Name:                 data.Name.ValueString(),
+		Name:                 data.Name.ValueASCII(),
 		Description:          data.Description.ValueString(),
 		Tags:                 tags,
 		SpamAggressiveness:   data.SpamAggressiveness.ValueString(),
 		GreylistingEnabled:   data.GreylistingEnabled.ValueBool(),
 		MXProxyEnabled:       data.MXProxyEnabled.ValueBool(),
 		HostedDNS:            data.HostedDNS.ValueBool(),
-		SenderAllowlist:      senderAllowlist,
-		SenderDenylist:       senderDenylist,
-		RecipientDenylist:    recipientDenylistSlice,
-		CatchallDestinations: catchallDestinations,
+		SenderAllowlist:      addressesToASCII(senderAllowlist),
+		SenderDenylist:       addressesToASCII(senderDenylist),
+		RecipientDenylist:    addressesToASCII(recipientDenylistSlice),
+		CatchallDestinations: addressesToASCII(catchallDestinations),
+	}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	// Unset catch-all destinations may be managed by migadu_domain_catchall,
+	// so send back what Migadu has rather than the last refreshed value.
+	if configuredCatchall.IsNull() {
+		current, err := r.client.GetDomain(ctx, domain)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "read domain", err)
+			return
+		}
+		domain.CatchallDestinations = current.CatchallDestinations
 	}
 
 	_, err := r.client.UpdateDomain(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "update domain", err)
 		return
 	}
 
 	// Note: state is intentionally not updated as it is a computed field that causes
 	// provider consistency errors. It will refresh on the next read.
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainResourceIdentityModel{
+		Name: data.Name.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -303,13 +429,18 @@ func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *DomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	importStateByKey(ctx, req, resp, "name")
 }
 
+// normalizeStringSlice returns a sorted copy of values without duplicates, so
+// that lists read from Migadu come out the same however the API orders them.
+// A nil slice becomes an empty one.
 func normalizeStringSlice(values []string) []string {
-	if values == nil {
+	normalized := slices.Clone(values)
+	if normalized == nil {
 		return []string{}
 	}
-	return values
-}
 
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
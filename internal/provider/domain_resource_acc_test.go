@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/MrLemur/terraform-provider-migadu/internal/migadutest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+// Domains cannot be deleted through the Migadu API, so these tests only run
+// against the fake API.
+
+func TestAccDomainResource_basic(t *testing.T) {
+	testFakeSetup(t)
+
+	resourceName := "migadu_domain.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainConfig("Terraform acceptance"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tfacc.example.net"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Terraform acceptance"),
+					resource.TestCheckResourceAttr(resourceName, "state", "pending"),
+					resource.TestCheckResourceAttr(resourceName, "catchall_destinations.0", "admin@tfacc.example.net"),
+				),
+			},
+			{
+				Config: testAccDomainConfig("Terraform acceptance updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Terraform acceptance updated"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        "tfacc.example.net",
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+func TestAccDomainResource_idn(t *testing.T) {
+	testFakeSetup(t)
+
+	resourceName := "migadu_domain.test"
+
+	// The name and addresses are sent to the API in punycode and read back in
+	// that form, which is not a diff from the Unicode form in configuration.
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainIDNConfig("bücher.example"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "bücher.example"),
+					resource.TestCheckResourceAttr(resourceName, "name_ascii", "xn--bcher-kva.example"),
+					resource.TestCheckResourceAttr(resourceName, "name_unicode", "bücher.example"),
+					resource.TestCheckResourceAttr(resourceName, "catchall_destinations.0", "admin@bücher.example"),
+					resource.TestCheckResourceAttr("data.migadu_domain.test", "name_ascii", "xn--bcher-kva.example"),
+					resource.TestCheckResourceAttr("data.migadu_domain.test", "name_unicode", "bücher.example"),
+					resource.TestCheckResourceAttr("migadu_alias.test", "address", "info@bücher.example"),
+					resource.TestCheckResourceAttr("migadu_alias.test", "destinations.0", "admin@bücher.example"),
+				),
+			},
+			{
+				Config:   testAccDomainIDNConfig("bücher.example"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        "bücher.example",
+				ImportStateVerifyIdentifierAttribute: "name",
+				// Imported addresses are in the punycode form Migadu returns.
+				ImportStateVerifyIgnore: []string{"catchall_destinations"},
+			},
+		},
+	})
+}
+
+func TestAccDomainActivationResource_basic(t *testing.T) {
+	testFakeSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainConfig("Terraform acceptance") + `
+resource "migadu_domain_activation" "test" {
+  domain_name = migadu_domain.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_domain_activation.test", "state", "active"),
+				),
+			},
+			{
+				ResourceName:                         "migadu_domain_activation.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        "tfacc.example.net",
+				ImportStateVerifyIdentifierAttribute: "domain_name",
+			},
+		},
+	})
+}
+
+func TestAccDomainActivationResource_timeouts(t *testing.T) {
+	testFakeSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainActivationConfig(false, ""),
+				Check:  resource.TestCheckResourceAttr("migadu_domain_activation.test", "state", "active"),
+			},
+			{
+				// Changing only the timeouts updates the activation in place.
+				Config: testAccDomainActivationConfig(false, "10m"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("migadu_domain_activation.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_domain_activation.test", "state", "active"),
+					resource.TestCheckResourceAttr("migadu_domain_activation.test", "timeouts.create", "10m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainActivationResource_waitForDNS(t *testing.T) {
+	server := testFakeSetup(t)
+	setFastDNSPolling(t)
+
+	failing := migadutest.Diagnostics{DKIM: []string{"DKIM record is missing"}}
+	if err := server.SetDiagnostics(testFakeDomain, failing); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDomainActivationConfig(false, ""),
+				ExpectError: regexp.MustCompile(`DNS Validation Failed`),
+			},
+			{
+				PreConfig: func() {
+					// Fix the records a little while after the apply starts.
+					time.AfterFunc(300*time.Millisecond, func() {
+						_ = server.SetDiagnostics(testFakeDomain, migadutest.Diagnostics{})
+					})
+				},
+				Config: testAccDomainActivationConfig(true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_domain_activation.test", "state", "active"),
+					resource.TestCheckResourceAttr("migadu_domain_activation.test", "wait_for_dns", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainActivationResource_enableWaitForDNS(t *testing.T) {
+	testFakeSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainActivationConfig(false, ""),
+				Check:  resource.TestCheckResourceAttr("migadu_domain_activation.test", "wait_for_dns", "false"),
+			},
+			{
+				// The domain is already active, so enabling wait_for_dns only
+				// updates state.
+				Config: testAccDomainActivationConfig(true, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("migadu_domain_activation.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_domain_activation.test", "state", "active"),
+					resource.TestCheckResourceAttr("migadu_domain_activation.test", "wait_for_dns", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainActivationResource_waitForDNSTimeout(t *testing.T) {
+	server := testFakeSetup(t)
+	setFastDNSPolling(t)
+
+	failing := migadutest.Diagnostics{
+		SPF:  []string{"SPF record is missing"},
+		DKIM: []string{"DKIM record is missing"},
+	}
+	if err := server.SetDiagnostics(testFakeDomain, failing); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDomainActivationConfig(true, "1s"),
+				ExpectError: regexp.MustCompile(`(?s)DNS Validation Timed Out.*SPF: SPF record is missing.*DKIM: DKIM record is missing`),
+			},
+		},
+	})
+}
+
+// setFastDNSPolling shortens the wait_for_dns poll interval for the duration
+// of a test.
+func setFastDNSPolling(t *testing.T) {
+	t.Helper()
+
+	previous := dnsPollInterval
+	dnsPollInterval = 50 * time.Millisecond
+	t.Cleanup(func() { dnsPollInterval = previous })
+}
+
+func testAccDomainActivationConfig(waitForDNS bool, createTimeout string) string {
+	timeouts := ""
+	if createTimeout != "" {
+		timeouts = fmt.Sprintf(`
+  timeouts {
+    create = "%s"
+  }
+`, createTimeout)
+	}
+
+	return fmt.Sprintf(`
+resource "migadu_domain_activation" "test" {
+  domain_name  = "%s"
+  wait_for_dns = %t
+%s}
+`, testFakeDomain, waitForDNS, timeouts)
+}
+
+func testAccDomainConfig(description string) string {
+	return `
+resource "migadu_domain" "test" {
+  name                  = "tfacc.example.net"
+  description           = "` + description + `"
+  tags                  = ["terraform"]
+  catchall_destinations = ["admin@tfacc.example.net"]
+}
+`
+}
+
+func testAccDomainIDNConfig(name string) string {
+	return fmt.Sprintf(`
+resource "migadu_domain" "test" {
+  name                  = "%[1]s"
+  catchall_destinations = ["admin@%[1]s"]
+}
+
+data "migadu_domain" "test" {
+  name = migadu_domain.test.name_unicode
+}
+
+resource "migadu_alias" "test" {
+  address      = "info@${migadu_domain.test.name}"
+  destinations = ["admin@%[1]s"]
+}
+`, name)
+}
@@ -59,18 +59,19 @@ func TestDomainResourceDeleteAddsWarning(t *testing.T) {
 
 	state := newStateForSchema(schemaResp.Schema)
 	diags := state.Set(context.Background(), &DomainResourceModel{
-		Name:                 types.StringValue("example.com"),
+		Name:                 NewDomainNameValue("example.com"),
 		State:                types.StringNull(),
 		Description:          types.StringNull(),
-		Tags:                 types.ListNull(types.StringType),
+		Tags:                 NewUnorderedListNull(types.StringType),
 		SpamAggressiveness:   types.StringNull(),
 		GreylistingEnabled:   types.BoolNull(),
 		MXProxyEnabled:       types.BoolNull(),
 		HostedDNS:            types.BoolNull(),
-		SenderAllowlist:      types.ListNull(types.StringType),
-		SenderDenylist:       types.ListNull(types.StringType),
-		RecipientDenylist:    types.ListNull(types.StringType),
-		CatchallDestinations: types.ListNull(types.StringType),
+		SenderAllowlist:      NewUnorderedListNull(EmailAddressType{}),
+		SenderDenylist:       NewUnorderedListNull(EmailAddressType{}),
+		RecipientDenylist:    NewUnorderedListNull(EmailAddressType{}),
+		CatchallDestinations: NewUnorderedListNull(EmailAddressType{}),
+		Timeouts:             nullTimeouts(t, schemaResp.Schema),
 	})
 	if diags.HasError() {
 		t.Fatalf("failed preparing delete state: %v", diags)
@@ -27,6 +27,8 @@ type DomainsDataSourceModel struct {
 
 type DomainListItemModel struct {
 	Name                 types.String `tfsdk:"name"`
+	NameASCII            types.String `tfsdk:"name_ascii"`
+	NameUnicode          types.String `tfsdk:"name_unicode"`
 	State                types.String `tfsdk:"state"`
 	Description          types.String `tfsdk:"description"`
 	SpamAggressiveness   types.String `tfsdk:"spam_aggressiveness"`
@@ -52,9 +54,11 @@ func (d *DomainsDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"name":        schema.StringAttribute{MarkdownDescription: "The domain name.", Computed: true},
-						"state":       schema.StringAttribute{MarkdownDescription: "Domain state.", Computed: true},
-						"description": schema.StringAttribute{MarkdownDescription: "Domain description.", Computed: true},
+						"name":         schema.StringAttribute{MarkdownDescription: "The domain name.", Computed: true},
+						"name_ascii":   schema.StringAttribute{MarkdownDescription: "The domain name in ASCII form, with internationalized labels in punycode.", Computed: true},
+						"name_unicode": schema.StringAttribute{MarkdownDescription: "The domain name in Unicode form.", Computed: true},
+						"state":        schema.StringAttribute{MarkdownDescription: "Domain state.", Computed: true},
+						"description":  schema.StringAttribute{MarkdownDescription: "Domain description.", Computed: true},
 						"spam_aggressiveness": schema.StringAttribute{
 							MarkdownDescription: "Spam filter aggressiveness level.",
 							Computed:            true,
@@ -72,24 +76,24 @@ func (d *DomainsDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 							Computed:            true,
 						},
 						"sender_allowlist": schema.ListAttribute{
-							MarkdownDescription: "List of allowed sender addresses.",
+							MarkdownDescription: "List of allowed sender addresses or domains.",
 							Computed:            true,
-							ElementType:         types.StringType,
+							ElementType:         AddressOrDomainType{},
 						},
 						"sender_denylist": schema.ListAttribute{
-							MarkdownDescription: "List of denied sender addresses.",
+							MarkdownDescription: "List of denied sender addresses or domains.",
 							Computed:            true,
-							ElementType:         types.StringType,
+							ElementType:         AddressOrDomainType{},
 						},
 						"recipient_denylist": schema.ListAttribute{
-							MarkdownDescription: "List of denied recipient addresses.",
+							MarkdownDescription: "List of denied recipient addresses or domains.",
 							Computed:            true,
-							ElementType:         types.StringType,
+							ElementType:         AddressOrDomainType{},
 						},
 						"catchall_destinations": schema.ListAttribute{
 							MarkdownDescription: "Catchall email destinations.",
 							Computed:            true,
-							ElementType:         types.StringType,
+							ElementType:         EmailAddressType{},
 						},
 					},
 				},
@@ -124,23 +128,26 @@ func (d *DomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	domains, err := d.client.ListDomains(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domains, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "list domains", err)
 		return
 	}
 
 	items := make([]DomainListItemModel, 0, len(domains))
 	for _, domain := range domains {
-		senderAllowlist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(domain.SenderAllowlist))
+		nameASCII, nameUnicode := domainNameForms(domain.Name)
+		senderAllowlist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(domain.SenderAllowlist))
 		resp.Diagnostics.Append(diags...)
-		senderDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(domain.SenderDenylist))
+		senderDenylist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(domain.SenderDenylist))
 		resp.Diagnostics.Append(diags...)
-		recipientDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(domain.RecipientDenylist))
+		recipientDenylist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(domain.RecipientDenylist))
 		resp.Diagnostics.Append(diags...)
-		catchallDestinations, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(domain.CatchallDestinations))
+		catchallDestinations, diags := types.ListValueFrom(ctx, EmailAddressType{}, normalizeStringSlice(domain.CatchallDestinations))
 		resp.Diagnostics.Append(diags...)
 
 		items = append(items, DomainListItemModel{
 			Name:                 types.StringValue(domain.Name),
+			NameASCII:            nameASCII,
+			NameUnicode:          nameUnicode,
 			State:                types.StringValue(domain.State),
 			Description:          types.StringValue(domain.Description),
 			SpamAggressiveness:   types.StringValue(domain.SpamAggressiveness),
@@ -157,16 +164,18 @@ func (d *DomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	domainsList, diags := types.ListValueFrom(ctx, types.ObjectType{
 		AttrTypes: map[string]attr.Type{
 			"name":                  types.StringType,
+			"name_ascii":            types.StringType,
+			"name_unicode":          types.StringType,
 			"state":                 types.StringType,
 			"description":           types.StringType,
 			"spam_aggressiveness":   types.StringType,
 			"greylisting_enabled":   types.BoolType,
 			"mx_proxy_enabled":      types.BoolType,
 			"hosted_dns":            types.BoolType,
-			"sender_allowlist":      types.ListType{ElemType: types.StringType},
-			"sender_denylist":       types.ListType{ElemType: types.StringType},
-			"recipient_denylist":    types.ListType{ElemType: types.StringType},
-			"catchall_destinations": types.ListType{ElemType: types.StringType},
+			"sender_allowlist":      types.ListType{ElemType: AddressOrDomainType{}},
+			"sender_denylist":       types.ListType{ElemType: AddressOrDomainType{}},
+			"recipient_denylist":    types.ListType{ElemType: AddressOrDomainType{}},
+			"catchall_destinations": types.ListType{ElemType: EmailAddressType{}},
 		},
 	}, items)
 	resp.Diagnostics.Append(diags...)
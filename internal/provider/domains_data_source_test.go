@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewDomainsDataSourceMetadata(t *testing.T) {
@@ -40,3 +41,21 @@ func TestNewDomainsDataSourceSchemaExpectations(t *testing.T) {
 	requireDataSourceStringAttribute(t, nestedAttrs, "state", false, true)
 	requireDataSourceStringAttribute(t, nestedAttrs, "description", false, true)
 }
+
+func TestAccDomainsDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "migadu_domains" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.migadu_domains.test", "domains.*", map[string]string{
+						"name": domainName,
+					}),
+				),
+			},
+		},
+	})
+}
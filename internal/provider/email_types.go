@@ -0,0 +1,453 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the custom string types and values satisfy framework interfaces.
+var _ basetypes.StringTypable = EmailAddressType{}
+var _ basetypes.StringValuableWithSemanticEquals = EmailAddressValue{}
+var _ xattr.ValidateableAttribute = EmailAddressValue{}
+var _ basetypes.StringTypable = LocalPartType{}
+var _ basetypes.StringValuableWithSemanticEquals = LocalPartValue{}
+var _ xattr.ValidateableAttribute = LocalPartValue{}
+var _ basetypes.StringTypable = DomainNameType{}
+var _ basetypes.StringValuableWithSemanticEquals = DomainNameValue{}
+var _ xattr.ValidateableAttribute = DomainNameValue{}
+var _ basetypes.StringTypable = AddressOrDomainType{}
+var _ basetypes.StringValuableWithSemanticEquals = AddressOrDomainValue{}
+var _ xattr.ValidateableAttribute = AddressOrDomainValue{}
+
+const (
+	maxLocalPartLength  = 64
+	maxDomainNameLength = 253
+)
+
+var (
+	// localPartRegexp matches dot-separated runs of the characters allowed
+	// in an unquoted local part, except for the slash, which would make
+	// import IDs ambiguous.
+	localPartRegexp = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+=?^_`{|}~-]+(\\.[A-Za-z0-9!#$%&'*+=?^_`{|}~-]+)*$")
+
+	// domainLabelRegexp matches a single DNS label.
+	domainLabelRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+)
+
+// validateLocalPart returns an error if localPart is not the local part of
+// an email address.
+func validateLocalPart(localPart string) error {
+	if len(localPart) > maxLocalPartLength {
+		return fmt.Errorf("%q is longer than %d characters", localPart, maxLocalPartLength)
+	}
+	if !localPartRegexp.MatchString(localPart) {
+		return fmt.Errorf("%q is not a valid local part, the part of an email address before the @", localPart)
+	}
+	return nil
+}
+
+// validateDomainName returns an error if name is not a domain name with at
+// least two labels, such as example.com. Internationalized names are
+// validated in their ASCII form.
+func validateDomainName(name string) error {
+	ascii, err := domainToASCII(name)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid domain name: %w", name, err)
+	}
+	if len(ascii) > maxDomainNameLength {
+		return fmt.Errorf("%q is longer than %d characters", name, maxDomainNameLength)
+	}
+
+	labels := strings.Split(ascii, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("%q is not a valid domain name such as example.com", name)
+	}
+	for _, label := range labels {
+		if !domainLabelRegexp.MatchString(label) {
+			return fmt.Errorf("%q is not a valid domain name such as example.com", name)
+		}
+	}
+	return nil
+}
+
+// validateEmailAddress returns an error if address is not an email address
+// with a valid local part and domain name.
+func validateEmailAddress(address string) error {
+	localPart, domain, found := strings.Cut(address, "@")
+	if !found || strings.Contains(domain, "@") {
+		return fmt.Errorf("%q is not a valid email address such as alice@example.com", address)
+	}
+	if err := validateLocalPart(localPart); err != nil {
+		return fmt.Errorf("%q is not a valid email address: %w", address, err)
+	}
+	if err := validateDomainName(domain); err != nil {
+		return fmt.Errorf("%q is not a valid email address: %w", address, err)
+	}
+	return nil
+}
+
+// validateAddressOrDomain returns an error if value is neither an email
+// address nor a domain name.
+func validateAddressOrDomain(value string) error {
+	if strings.Contains(value, "@") {
+		return validateEmailAddress(value)
+	}
+	if err := validateDomainName(value); err != nil {
+		return fmt.Errorf("%q is not a valid email address such as alice@example.com or domain name such as example.com", value)
+	}
+	return nil
+}
+
+// stringSemanticEquals reports whether two known strings have the same key.
+// Migadu stores addresses and domains in lowercase ASCII, so a value written
+// in another case or with Unicode labels is not a change. Null and unknown
+// values are only equal to themselves.
+func stringSemanticEquals(oldValue, newValue basetypes.StringValue, key func(string) string) bool {
+	if oldValue.IsNull() || oldValue.IsUnknown() || newValue.IsNull() || newValue.IsUnknown() {
+		return oldValue.Equal(newValue)
+	}
+	return key(oldValue.ValueString()) == key(newValue.ValueString())
+}
+
+func semanticEqualityError(expected, got any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.AddError(
+		"Semantic Equality Check Error",
+		"An unexpected value type was received while performing semantic equality checks. "+
+			"Please report this to the provider developers.\n\n"+
+			"Expected Value Type: "+fmt.Sprintf("%T", expected)+"\n"+
+			"Got Value Type: "+fmt.Sprintf("%T", got),
+	)
+	return diags
+}
+
+// validateStringValue adds an attribute error to resp if value is known and
+// validate rejects it.
+func validateStringValue(value basetypes.StringValue, validate func(string) error, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	if err := validate(value.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", err.Error())
+	}
+}
+
+// EmailAddressType is a string type for email addresses such as
+// alice@example.com. Values are validated and compare equal regardless of
+// case. The empty string is accepted, since Migadu uses it for an address
+// that is not set, such as a mailbox without a password recovery email.
+type EmailAddressType struct {
+	basetypes.StringType
+}
+
+func (t EmailAddressType) Equal(o attr.Type) bool {
+	_, ok := o.(EmailAddressType)
+	return ok
+}
+
+func (t EmailAddressType) String() string {
+	return "EmailAddressType"
+}
+
+func (t EmailAddressType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return EmailAddressValue{StringValue: in}, nil
+}
+
+func (t EmailAddressType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return EmailAddressValue{StringValue: stringValue}, nil
+}
+
+func (t EmailAddressType) ValueType(ctx context.Context) attr.Value {
+	return EmailAddressValue{}
+}
+
+// EmailAddressValue is a value of EmailAddressType.
+type EmailAddressValue struct {
+	basetypes.StringValue
+}
+
+func NewEmailAddressValue(value string) EmailAddressValue {
+	return EmailAddressValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func NewEmailAddressNull() EmailAddressValue {
+	return EmailAddressValue{StringValue: basetypes.NewStringNull()}
+}
+
+// ValueASCII returns the address with its domain in ASCII form, as sent to
+// the Migadu API.
+func (v EmailAddressValue) ValueASCII() string {
+	return addressToASCII(v.ValueString())
+}
+
+func (v EmailAddressValue) Type(ctx context.Context) attr.Type {
+	return EmailAddressType{}
+}
+
+func (v EmailAddressValue) Equal(o attr.Value) bool {
+	other, ok := o.(EmailAddressValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v EmailAddressValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(EmailAddressValue)
+	if !ok {
+		return false, semanticEqualityError(v, newValuable)
+	}
+
+	return stringSemanticEquals(v.StringValue, newValue.StringValue, addressToASCII), nil
+}
+
+func (v EmailAddressValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.ValueString() == "" {
+		return
+	}
+	validateStringValue(v.StringValue, validateEmailAddress, req, resp)
+}
+
+// LocalPartType is a string type for the local part of an email address,
+// the part before the @. Values are validated and compare equal regardless
+// of case.
+type LocalPartType struct {
+	basetypes.StringType
+}
+
+func (t LocalPartType) Equal(o attr.Type) bool {
+	_, ok := o.(LocalPartType)
+	return ok
+}
+
+func (t LocalPartType) String() string {
+	return "LocalPartType"
+}
+
+func (t LocalPartType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return LocalPartValue{StringValue: in}, nil
+}
+
+func (t LocalPartType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return LocalPartValue{StringValue: stringValue}, nil
+}
+
+func (t LocalPartType) ValueType(ctx context.Context) attr.Value {
+	return LocalPartValue{}
+}
+
+// LocalPartValue is a value of LocalPartType.
+type LocalPartValue struct {
+	basetypes.StringValue
+}
+
+func NewLocalPartValue(value string) LocalPartValue {
+	return LocalPartValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v LocalPartValue) Type(ctx context.Context) attr.Type {
+	return LocalPartType{}
+}
+
+func (v LocalPartValue) Equal(o attr.Value) bool {
+	other, ok := o.(LocalPartValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v LocalPartValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(LocalPartValue)
+	if !ok {
+		return false, semanticEqualityError(v, newValuable)
+	}
+
+	return stringSemanticEquals(v.StringValue, newValue.StringValue, strings.ToLower), nil
+}
+
+func (v LocalPartValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	validateStringValue(v.StringValue, validateLocalPart, req, resp)
+}
+
+// DomainNameType is a string type for domain names such as example.com.
+// Values are validated and compare equal regardless of case.
+type DomainNameType struct {
+	basetypes.StringType
+}
+
+func (t DomainNameType) Equal(o attr.Type) bool {
+	_, ok := o.(DomainNameType)
+	return ok
+}
+
+func (t DomainNameType) String() string {
+	return "DomainNameType"
+}
+
+func (t DomainNameType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DomainNameValue{StringValue: in}, nil
+}
+
+func (t DomainNameType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return DomainNameValue{StringValue: stringValue}, nil
+}
+
+func (t DomainNameType) ValueType(ctx context.Context) attr.Value {
+	return DomainNameValue{}
+}
+
+// DomainNameValue is a value of DomainNameType.
+type DomainNameValue struct {
+	basetypes.StringValue
+}
+
+func NewDomainNameValue(value string) DomainNameValue {
+	return DomainNameValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// ValueASCII returns the domain name in ASCII form, as sent to the Migadu
+// API.
+func (v DomainNameValue) ValueASCII() string {
+	return domainKey(v.ValueString())
+}
+
+func (v DomainNameValue) Type(ctx context.Context) attr.Type {
+	return DomainNameType{}
+}
+
+func (v DomainNameValue) Equal(o attr.Value) bool {
+	other, ok := o.(DomainNameValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v DomainNameValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(DomainNameValue)
+	if !ok {
+		return false, semanticEqualityError(v, newValuable)
+	}
+
+	return stringSemanticEquals(v.StringValue, newValue.StringValue, domainKey), nil
+}
+
+func (v DomainNameValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	validateStringValue(v.StringValue, validateDomainName, req, resp)
+}
+
+// AddressOrDomainType is a string type for entries of sender and recipient
+// allow and deny lists, which are either email addresses such as
+// alice@example.com or domain names such as example.com. Values are
+// validated and compare equal regardless of case.
+type AddressOrDomainType struct {
+	basetypes.StringType
+}
+
+func (t AddressOrDomainType) Equal(o attr.Type) bool {
+	_, ok := o.(AddressOrDomainType)
+	return ok
+}
+
+func (t AddressOrDomainType) String() string {
+	return "AddressOrDomainType"
+}
+
+func (t AddressOrDomainType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return AddressOrDomainValue{StringValue: in}, nil
+}
+
+func (t AddressOrDomainType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return AddressOrDomainValue{StringValue: stringValue}, nil
+}
+
+func (t AddressOrDomainType) ValueType(ctx context.Context) attr.Value {
+	return AddressOrDomainValue{}
+}
+
+// AddressOrDomainValue is a value of AddressOrDomainType.
+type AddressOrDomainValue struct {
+	basetypes.StringValue
+}
+
+func NewAddressOrDomainValue(value string) AddressOrDomainValue {
+	return AddressOrDomainValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v AddressOrDomainValue) Type(ctx context.Context) attr.Type {
+	return AddressOrDomainType{}
+}
+
+func (v AddressOrDomainValue) Equal(o attr.Value) bool {
+	other, ok := o.(AddressOrDomainValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v AddressOrDomainValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(AddressOrDomainValue)
+	if !ok {
+		return false, semanticEqualityError(v, newValuable)
+	}
+
+	return stringSemanticEquals(v.StringValue, newValue.StringValue, addressToASCII), nil
+}
+
+func (v AddressOrDomainValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	validateStringValue(v.StringValue, validateAddressOrDomain, req, resp)
+}
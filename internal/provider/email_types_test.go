@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestEmailTypesValidateAttribute(t *testing.T) {
+	testCases := map[string]struct {
+		value       xattr.ValidateableAttribute
+		expectError bool
+	}{
+		"address":                       {value: NewEmailAddressValue("alice@example.com")},
+		"address with plus":             {value: NewEmailAddressValue("alice+news@mail.example.com")},
+		"address in uppercase":          {value: NewEmailAddressValue("Alice@Example.COM")},
+		"empty address":                 {value: NewEmailAddressValue("")},
+		"null address":                  {value: NewEmailAddressNull()},
+		"address without at":            {value: NewEmailAddressValue("example.com"), expectError: true},
+		"address with two ats":          {value: NewEmailAddressValue("alice@bob@example.com"), expectError: true},
+		"address with whitespace":       {value: NewEmailAddressValue("alice smith@example.com"), expectError: true},
+		"address without tld":           {value: NewEmailAddressValue("alice@localhost"), expectError: true},
+		"local part":                    {value: NewLocalPartValue("alice.smith")},
+		"local part with symbols":       {value: NewLocalPartValue("no-reply_1+tag")},
+		"empty local part":              {value: NewLocalPartValue(""), expectError: true},
+		"local part with leading dot":   {value: NewLocalPartValue(".alice"), expectError: true},
+		"local part with double dot":    {value: NewLocalPartValue("alice..smith"), expectError: true},
+		"local part with slash":         {value: NewLocalPartValue("alice/smith"), expectError: true},
+		"local part too long":           {value: NewLocalPartValue(strings.Repeat("a", 65)), expectError: true},
+		"domain name":                   {value: NewDomainNameValue("example.com")},
+		"domain name with subdomain":    {value: NewDomainNameValue("mail.example-1.co.uk")},
+		"domain name with single label": {value: NewDomainNameValue("example"), expectError: true},
+		"domain name with empty label":  {value: NewDomainNameValue("example..com"), expectError: true},
+		"domain name with hyphen edge":  {value: NewDomainNameValue("-example.com"), expectError: true},
+		"domain name with underscore":   {value: NewDomainNameValue("ex_ample.com"), expectError: true},
+		"domain name label too long":    {value: NewDomainNameValue(strings.Repeat("a", 64) + ".com"), expectError: true},
+		"unicode domain name":           {value: NewDomainNameValue("bücher.example")},
+		"punycode domain name":          {value: NewDomainNameValue("xn--bcher-kva.example")},
+		"invalid punycode domain name":  {value: NewDomainNameValue("xn--a.example"), expectError: true},
+		"address with unicode domain":   {value: NewEmailAddressValue("amy@bücher.example")},
+		"unknown domain name":           {value: DomainNameValue{StringValue: basetypes.NewStringUnknown()}},
+		"list entry address":            {value: NewAddressOrDomainValue("alice@example.com")},
+		"list entry domain":             {value: NewAddressOrDomainValue("example.com")},
+		"list entry unicode domain":     {value: NewAddressOrDomainValue("bücher.example")},
+		"list entry invalid address":    {value: NewAddressOrDomainValue("alice@example"), expectError: true},
+		"list entry invalid domain":     {value: NewAddressOrDomainValue("example"), expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := xattr.ValidateAttributeRequest{Path: path.Root("test")}
+			var resp xattr.ValidateAttributeResponse
+
+			tc.value.ValidateAttribute(context.Background(), req, &resp)
+
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error %t, got diagnostics: %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestEmailTypesStringSemanticEquals(t *testing.T) {
+	testCases := map[string]struct {
+		prior    basetypes.StringValuableWithSemanticEquals
+		new      basetypes.StringValuable
+		expected bool
+	}{
+		"same address":         {prior: NewEmailAddressValue("alice@example.com"), new: NewEmailAddressValue("alice@example.com"), expected: true},
+		"address case differs": {prior: NewEmailAddressValue("Alice@Example.COM"), new: NewEmailAddressValue("alice@example.com"), expected: true},
+		"address differs":      {prior: NewEmailAddressValue("alice@example.com"), new: NewEmailAddressValue("bob@example.com"), expected: false},
+		"null and empty":       {prior: NewEmailAddressNull(), new: NewEmailAddressValue(""), expected: false},
+		"local part case":      {prior: NewLocalPartValue("Alice"), new: NewLocalPartValue("alice"), expected: true},
+		"domain name case":     {prior: NewDomainNameValue("Example.COM"), new: NewDomainNameValue("example.com"), expected: true},
+		"domain name differs":  {prior: NewDomainNameValue("example.com"), new: NewDomainNameValue("example.org"), expected: false},
+		"domain name unicode":  {prior: NewDomainNameValue("Bücher.example"), new: NewDomainNameValue("xn--bcher-kva.example"), expected: true},
+		"address unicode":      {prior: NewEmailAddressValue("Amy@bücher.example"), new: NewEmailAddressValue("amy@xn--bcher-kva.example"), expected: true},
+		"list entry address":   {prior: NewAddressOrDomainValue("Alice@Example.COM"), new: NewAddressOrDomainValue("alice@example.com"), expected: true},
+		"list entry domain":    {prior: NewAddressOrDomainValue("Bücher.example"), new: NewAddressOrDomainValue("xn--bcher-kva.example"), expected: true},
+		"list entry differs":   {prior: NewAddressOrDomainValue("example.com"), new: NewAddressOrDomainValue("alice@example.com"), expected: false},
+		"unknown and known":    {prior: DomainNameValue{StringValue: basetypes.NewStringUnknown()}, new: NewDomainNameValue("example.com"), expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := tc.prior.StringSemanticEquals(context.Background(), tc.new)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEmailTypesStringSemanticEqualsWrongType(t *testing.T) {
+	_, diags := NewEmailAddressValue("alice@example.com").StringSemanticEquals(context.Background(), NewDomainNameValue("example.com"))
+	if !diags.HasError() {
+		t.Fatal("expected an error comparing values of different types")
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// apiErrorKind classifies a failed Migadu API call.
+type apiErrorKind int
+
+const (
+	apiErrorUnknown apiErrorKind = iota
+	apiErrorAuth
+	apiErrorNotFound
+	apiErrorConflict
+	apiErrorValidation
+	apiErrorRateLimited
+	apiErrorServer
+)
+
+// validationFieldRegexp matches Migadu validation messages that start with
+// the name of the offending field, such as "password can't be blank" or
+// "Local part is invalid".
+var validationFieldRegexp = regexp.MustCompile(`^([A-Za-z][A-Za-z_ ]*?)\s+(?:can't|cannot|is|are|must|has|have|should|does|doesn't|was|not)\b`)
+
+// classifyAPIError returns the kind of err and, for validation errors, the
+// snake_case name of the field the API complained about, if any.
+func classifyAPIError(err error) (apiErrorKind, string) {
+	var apiErr *migadu.APIError
+	if !errors.As(err, &apiErr) {
+		return apiErrorUnknown, ""
+	}
+
+	switch code := apiErr.StatusCode; {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return apiErrorAuth, ""
+	case code == http.StatusNotFound:
+		return apiErrorNotFound, ""
+	case code == http.StatusConflict || isAlreadyExistsMessage(apiErr.Message):
+		return apiErrorConflict, ""
+	case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+		return apiErrorValidation, validationField(apiErr.Message)
+	case code == http.StatusTooManyRequests:
+		return apiErrorRateLimited, ""
+	case code >= http.StatusInternalServerError:
+		return apiErrorServer, ""
+	default:
+		return apiErrorUnknown, ""
+	}
+}
+
+// isNotFoundError reports whether err is a Migadu API response indicating that
+// the requested object does not exist.
+func isNotFoundError(err error) bool {
+	kind, _ := classifyAPIError(err)
+	return kind == apiErrorNotFound
+}
+
+// isConflictError reports whether err is a Migadu API response indicating that
+// the object being created already exists.
+func isConflictError(err error) bool {
+	kind, _ := classifyAPIError(err)
+	return kind == apiErrorConflict
+}
+
+func isAlreadyExistsMessage(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "already exists") || strings.Contains(message, "has already been taken")
+}
+
+func validationField(message string) string {
+	match := validationFieldRegexp.FindStringSubmatch(strings.TrimSpace(message))
+	if match == nil {
+		return ""
+	}
+	return strings.ReplaceAll(strings.ToLower(match[1]), " ", "_")
+}
+
+// attributeSchema is satisfied by the schemas of plans, states and
+// configurations, and is used to check that a field named by the API exists.
+type attributeSchema interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// addClientError adds a diagnostic for a failed Migadu API call, described by
+// action such as "create mailbox". The summary reflects the kind of failure,
+// and validation errors are attached to the attribute they name when schema
+// has an attribute of that name. schema may be nil.
+func addClientError(ctx context.Context, diags *diag.Diagnostics, schema attributeSchema, action string, err error) {
+	kind, field := classifyAPIError(err)
+	detail := fmt.Sprintf("Unable to %s, got error: %s", action, err)
+
+	switch kind {
+	case apiErrorAuth:
+		diags.AddError("Migadu Authentication Failed", detail+"\n\n"+
+			"Check that the provider username and api_key, or the MIGADU_USERNAME and MIGADU_API_KEY environment variables, "+
+			"hold a valid Migadu admin email address and an API key that has not been revoked.")
+	case apiErrorNotFound:
+		diags.AddError("Migadu Object Not Found", detail)
+	case apiErrorConflict:
+		diags.AddError("Migadu Object Already Exists", detail+"\n\n"+
+			"Import the existing object into Terraform state, choose a different name, or set adopt_existing to take it over where the resource supports it.")
+	case apiErrorValidation:
+		if field != "" && schema != nil {
+			if _, typeDiags := schema.TypeAtPath(ctx, path.Root(field)); !typeDiags.HasError() {
+				diags.AddAttributeError(path.Root(field), "Invalid Attribute Value", detail)
+				return
+			}
+		}
+		diags.AddError("Migadu Validation Error", detail)
+	case apiErrorRateLimited:
+		diags.AddError("Migadu Rate Limit Exceeded", detail+"\n\n"+
+			"The request was still rate limited after retrying. Consider raising max_retries or retry_max_wait, "+
+			"or lowering max_concurrent_requests in the provider configuration.")
+	case apiErrorServer:
+		diags.AddError("Migadu Server Error", detail+"\n\n"+
+			"Migadu failed to process the request. This is usually temporary, so try again later.")
+	default:
+		diags.AddError("Client Error", detail)
+	}
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"not found": {
+			err:      &migadu.APIError{StatusCode: http.StatusNotFound, Message: "Not Found"},
+			expected: true,
+		},
+		"wrapped not found": {
+			err:      fmt.Errorf("get mailbox: %w", &migadu.APIError{StatusCode: http.StatusNotFound}),
+			expected: true,
+		},
+		"unauthorized": {
+			err:      &migadu.APIError{StatusCode: http.StatusUnauthorized},
+			expected: false,
+		},
+		"server error": {
+			err:      &migadu.APIError{StatusCode: http.StatusInternalServerError},
+			expected: false,
+		},
+		"network error": {
+			err:      errors.New("dial tcp: connection refused"),
+			expected: false,
+		},
+		"nil": {
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := isNotFoundError(tc.err); got != tc.expected {
+				t.Fatalf("expected isNotFoundError to return %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestClassifyAPIError(t *testing.T) {
+	testCases := map[string]struct {
+		err           error
+		expectedKind  apiErrorKind
+		expectedField string
+	}{
+		"unauthorized": {
+			err:          &migadu.APIError{StatusCode: http.StatusUnauthorized, Message: "Unauthorized"},
+			expectedKind: apiErrorAuth,
+		},
+		"forbidden": {
+			err:          &migadu.APIError{StatusCode: http.StatusForbidden},
+			expectedKind: apiErrorAuth,
+		},
+		"not found": {
+			err:          &migadu.APIError{StatusCode: http.StatusNotFound, Message: "Mailbox not found"},
+			expectedKind: apiErrorNotFound,
+		},
+		"conflict": {
+			err:          &migadu.APIError{StatusCode: http.StatusConflict, Message: "Address already exists"},
+			expectedKind: apiErrorConflict,
+		},
+		"already exists as validation error": {
+			err:          &migadu.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "Local part has already been taken"},
+			expectedKind: apiErrorConflict,
+		},
+		"validation with field": {
+			err:           &migadu.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "password can't be blank"},
+			expectedKind:  apiErrorValidation,
+			expectedField: "password",
+		},
+		"validation with multi-word field": {
+			err:           &migadu.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "Password recovery email is invalid"},
+			expectedKind:  apiErrorValidation,
+			expectedField: "password_recovery_email",
+		},
+		"validation without field": {
+			err:          &migadu.APIError{StatusCode: http.StatusBadRequest, Message: "invalid request body"},
+			expectedKind: apiErrorValidation,
+		},
+		"rate limited": {
+			err:          &migadu.APIError{StatusCode: http.StatusTooManyRequests},
+			expectedKind: apiErrorRateLimited,
+		},
+		"server error": {
+			err:          &migadu.APIError{StatusCode: http.StatusBadGateway},
+			expectedKind: apiErrorServer,
+		},
+		"wrapped": {
+			err:          fmt.Errorf("update mailbox: %w", &migadu.APIError{StatusCode: http.StatusServiceUnavailable}),
+			expectedKind: apiErrorServer,
+		},
+		"unexpected status": {
+			err:          &migadu.APIError{StatusCode: http.StatusTeapot},
+			expectedKind: apiErrorUnknown,
+		},
+		"network error": {
+			err:          errors.New("dial tcp: connection refused"),
+			expectedKind: apiErrorUnknown,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			kind, field := classifyAPIError(tc.err)
+			if kind != tc.expectedKind || field != tc.expectedField {
+				t.Fatalf("expected (%d, %q), got (%d, %q)", tc.expectedKind, tc.expectedField, kind, field)
+			}
+		})
+	}
+}
+
+func TestAddClientError(t *testing.T) {
+	schema := mustResourceSchema(t, NewMailboxResource()).Schema
+
+	testCases := map[string]struct {
+		err             error
+		expectedSummary string
+		expectedPath    path.Path
+		expectedDetail  string
+	}{
+		"auth hints at credentials": {
+			err:             &migadu.APIError{StatusCode: http.StatusUnauthorized, Message: "Unauthorized"},
+			expectedSummary: "Migadu Authentication Failed",
+			expectedDetail:  "MIGADU_API_KEY",
+		},
+		"validation on schema attribute": {
+			err:             &migadu.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "password can't be blank"},
+			expectedSummary: "Invalid Attribute Value",
+			expectedPath:    path.Root("password"),
+			expectedDetail:  "Unable to create mailbox",
+		},
+		"validation on unknown field": {
+			err:             &migadu.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "quota is exceeded"},
+			expectedSummary: "Migadu Validation Error",
+		},
+		"conflict": {
+			err:             &migadu.APIError{StatusCode: http.StatusConflict, Message: "Address already exists"},
+			expectedSummary: "Migadu Object Already Exists",
+		},
+		"rate limited": {
+			err:             &migadu.APIError{StatusCode: http.StatusTooManyRequests},
+			expectedSummary: "Migadu Rate Limit Exceeded",
+			expectedDetail:  "max_retries",
+		},
+		"network error": {
+			err:             errors.New("dial tcp: connection refused"),
+			expectedSummary: "Client Error",
+			expectedDetail:  "connection refused",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addClientError(context.Background(), &diags, schema, "create mailbox", tc.err)
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+			}
+			d := diags[0]
+
+			if d.Summary() != tc.expectedSummary {
+				t.Fatalf("expected summary %q, got %q", tc.expectedSummary, d.Summary())
+			}
+			if !strings.Contains(d.Detail(), tc.expectedDetail) {
+				t.Fatalf("expected detail to contain %q, got %q", tc.expectedDetail, d.Detail())
+			}
+
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			if len(tc.expectedPath.Steps()) == 0 {
+				if ok {
+					t.Fatalf("expected no attribute path, got %s", withPath.Path())
+				}
+				return
+			}
+			if !ok || !withPath.Path().Equal(tc.expectedPath) {
+				t.Fatalf("expected diagnostic on %s, got %v", tc.expectedPath, d)
+			}
+		})
+	}
+}
+
+func TestAddClientErrorWithoutSchema(t *testing.T) {
+	var diags diag.Diagnostics
+	addClientError(context.Background(), &diags, nil, "activate domain", &migadu.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "name is invalid"})
+
+	if _, ok := diags[0].(diag.DiagnosticWithPath); ok {
+		t.Fatal("expected no attribute path without a schema")
+	}
+	assertHasDiagnosticSummary(t, diags, "Migadu Validation Error")
+}
@@ -0,0 +1,447 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &ForwardingResource{}
+var _ resource.ResourceWithImportState = &ForwardingResource{}
+var _ resource.ResourceWithIdentity = &ForwardingResource{}
+
+func NewForwardingResource() resource.Resource {
+	return &ForwardingResource{}
+}
+
+type ForwardingResource struct {
+	client *migadu.Client
+}
+
+// confirmationPollInterval is how often the forwarding is checked when
+// wait_for_confirmation is enabled.
+var confirmationPollInterval = 30 * time.Second
+
+// defaultConfirmationWaitTimeout replaces defaultCreateTimeout when
+// wait_for_confirmation is enabled, to give the recipient time to confirm.
+const defaultConfirmationWaitTimeout = 30 * time.Minute
+
+type ForwardingResourceModel struct {
+	DomainName          DomainNameValue   `tfsdk:"domain_name"`
+	Mailbox             LocalPartValue    `tfsdk:"mailbox"`
+	Address             EmailAddressValue `tfsdk:"address"`
+	IsActive            types.Bool        `tfsdk:"is_active"`
+	ExpiresOn           types.String      `tfsdk:"expires_on"`
+	RemoveUponExpiry    types.Bool        `tfsdk:"remove_upon_expiry"`
+	ConfirmationSentAt  types.String      `tfsdk:"confirmation_sent_at"`
+	ConfirmedAt         types.String      `tfsdk:"confirmed_at"`
+	BlockedAt           types.String      `tfsdk:"blocked_at"`
+	WaitForConfirmation types.Bool        `tfsdk:"wait_for_confirmation"`
+	Timeouts            timeouts.Value    `tfsdk:"timeouts"`
+}
+
+// ForwardingResourceIdentityModel describes the resource identity data model.
+type ForwardingResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	Mailbox    types.String `tfsdk:"mailbox"`
+	Address    types.String `tfsdk:"address"`
+}
+
+func (r *ForwardingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_forwarding"
+}
+
+func (r *ForwardingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a forwarding of a Migadu mailbox to an external address.\n\n" +
+			"~> **Note:** Migadu sends a confirmation request to the destination address, and mail is only forwarded once the recipient has confirmed it.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				MarkdownDescription: "The domain name of the mailbox.",
+				CustomType:          DomainNameType{},
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mailbox": schema.StringAttribute{
+				MarkdownDescription: "The local part of the mailbox to forward.",
+				CustomType:          LocalPartType{},
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "The destination address mail is forwarded to.",
+				CustomType:          EmailAddressType{},
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the forwarding is active. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "Date in `YYYY-MM-DD` format after which the forwarding stops. Empty for no expiry.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					isDate(),
+				},
+			},
+			"remove_upon_expiry": schema.BoolAttribute{
+				MarkdownDescription: "Whether Migadu removes the forwarding once it expires. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"confirmation_sent_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the confirmation request was sent to the destination address.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"confirmed_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the recipient confirmed the forwarding. Empty while it is unconfirmed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"blocked_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the forwarding was blocked. Empty unless it is blocked.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"wait_for_confirmation": schema.BoolAttribute{
+				MarkdownDescription: "Keep polling the forwarding after creating it until the recipient confirms it. " +
+					"Waits up to the create timeout, which defaults to 30 minutes when this is enabled, and warns if it is still unconfirmed. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *ForwardingResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the mailbox.",
+				RequiredForImport: true,
+			},
+			"mailbox": identityschema.StringAttribute{
+				Description:       "The local part of the forwarded mailbox.",
+				RequiredForImport: true,
+			},
+			"address": identityschema.StringAttribute{
+				Description:       "The destination address of the forwarding.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *ForwardingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*migadu.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *migadu.Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ForwardingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ForwardingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defaultTimeout := defaultCreateTimeout
+	if data.WaitForConfirmation.ValueBool() {
+		defaultTimeout = defaultConfirmationWaitTimeout
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+	mailbox := &migadu.Mailbox{LocalPart: data.Mailbox.ValueString()}
+	forwarding := &migadu.Forwarding{
+		Address:          data.Address.ValueASCII(),
+		IsActive:         data.IsActive.ValueBool(),
+		ExpiresOn:        data.ExpiresOn.ValueString(),
+		RemoveUponExpiry: data.RemoveUponExpiry.ValueBool(),
+	}
+
+	created, err := r.createForwarding(ctx, domain, mailbox, forwarding)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "create forwarding", err)
+		return
+	}
+
+	for created.ConfirmedAt == "" && data.WaitForConfirmation.ValueBool() {
+		tflog.Info(ctx, "Waiting for forwarding to be confirmed", map[string]any{
+			"mailbox": mailbox.LocalPart + "@" + domain.Name,
+			"address": forwarding.Address,
+		})
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(confirmationPollInterval):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		latest, err := r.client.GetForwarding(ctx, domain, mailbox, forwarding)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "read forwarding", err)
+			break
+		}
+		created = latest
+	}
+
+	data.setComputed(created)
+	addUnconfirmedWarning(&resp.Diagnostics, data)
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ForwardingResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Mailbox:    data.Mailbox.StringValue,
+		Address:    data.Address.StringValue,
+	})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// createForwarding creates the forwarding while holding the domain lock,
+// which is released before waiting for confirmation.
+func (r *ForwardingResource) createForwarding(ctx context.Context, domain *migadu.Domain, mailbox *migadu.Mailbox, forwarding *migadu.Forwarding) (*migadu.Forwarding, error) {
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	return r.client.NewForwarding(ctx, domain, mailbox, forwarding)
+}
+
+func (r *ForwardingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ForwardingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ForwardingResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Mailbox:    data.Mailbox.StringValue,
+		Address:    data.Address.StringValue,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+	mailbox := &migadu.Mailbox{LocalPart: data.Mailbox.ValueString()}
+
+	forwarding, err := r.client.GetForwarding(ctx, domain, mailbox, &migadu.Forwarding{Address: data.Address.ValueASCII()})
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read forwarding", err)
+		return
+	}
+
+	data.Address = stateAddress(data.Address, forwarding.Address)
+	data.IsActive = types.BoolValue(forwarding.IsActive)
+	data.ExpiresOn = types.StringValue(forwarding.ExpiresOn)
+	data.RemoveUponExpiry = types.BoolValue(forwarding.RemoveUponExpiry)
+	data.setComputed(forwarding)
+
+	// wait_for_confirmation only affects create; imported forwardings take
+	// the default.
+	if data.WaitForConfirmation.IsNull() {
+		data.WaitForConfirmation = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ForwardingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ForwardingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+	forwarding := &migadu.Forwarding{
+		Address:          data.Address.ValueASCII(),
+		IsActive:         data.IsActive.ValueBool(),
+		ExpiresOn:        data.ExpiresOn.ValueString(),
+		RemoveUponExpiry: data.RemoveUponExpiry.ValueBool(),
+	}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	_, err := r.client.UpdateForwarding(ctx, domain, &migadu.Mailbox{LocalPart: data.Mailbox.ValueString()}, forwarding)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "update forwarding", err)
+		return
+	}
+
+	// The timestamps are planned from the prior state. The recipient may
+	// confirm at any time, so a changed value is left for the next refresh
+	// instead of contradicting the plan.
+	addUnconfirmedWarning(&resp.Diagnostics, data)
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ForwardingResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Mailbox:    data.Mailbox.StringValue,
+		Address:    data.Address.StringValue,
+	})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ForwardingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ForwardingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+	forwarding := &migadu.Forwarding{
+		Address: data.Address.ValueASCII(),
+	}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	err := r.client.DeleteForwarding(ctx, domain, &migadu.Mailbox{LocalPart: data.Mailbox.ValueString()}, forwarding)
+	// The forwarding may already be gone, such as when its mailbox was
+	// deleted first.
+	if err != nil && !isNotFoundError(err) {
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "delete forwarding", err)
+		return
+	}
+}
+
+func (r *ForwardingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Format: domain_name/mailbox/address or mailbox_address/address
+	if strings.Count(req.ID, "/") == 1 {
+		mailboxAddress, address, _ := strings.Cut(req.ID, "/")
+
+		mailbox, domain, err := splitAddress(mailboxAddress)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domain)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mailbox"), mailbox)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("address"), address)...)
+		return
+	}
+
+	importStateByKey(ctx, req, resp, "domain_name", "mailbox", "address")
+}
+
+// setComputed sets the attributes Migadu maintains from forwarding.
+func (m *ForwardingResourceModel) setComputed(forwarding *migadu.Forwarding) {
+	m.ConfirmationSentAt = types.StringValue(forwarding.ConfirmationSentAt)
+	m.ConfirmedAt = types.StringValue(forwarding.ConfirmedAt)
+	m.BlockedAt = types.StringValue(forwarding.BlockedAt)
+}
+
+// addUnconfirmedWarning warns that mail isn't forwarded to an address that
+// hasn't confirmed the forwarding yet.
+func addUnconfirmedWarning(diags *diag.Diagnostics, data ForwardingResourceModel) {
+	if data.ConfirmedAt.ValueString() != "" {
+		return
+	}
+
+	diags.AddWarning(
+		"Forwarding Not Confirmed",
+		fmt.Sprintf("The forwarding of %s@%s to %s has not been confirmed yet. Mail is only forwarded once the recipient confirms the request Migadu sent to %s.",
+			data.Mailbox.ValueString(), data.DomainName.ValueString(), data.Address.ValueString(), data.Address.ValueString()),
+	)
+}
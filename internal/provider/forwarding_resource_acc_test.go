@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccForwardingResource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resourceName := "migadu_forwarding.test"
+	mailboxLocalPart := fmt.Sprintf("tfacc-fwd-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccForwardingConfig(domainName, mailboxLocalPart, `
+  expires_on         = "2099-12-31"
+  remove_upon_expiry = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "domain_name", domainName),
+					resource.TestCheckResourceAttr(resourceName, "mailbox", mailboxLocalPart),
+					resource.TestCheckResourceAttr(resourceName, "address", "forward@example.net"),
+					resource.TestCheckResourceAttr(resourceName, "is_active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "expires_on", "2099-12-31"),
+					resource.TestCheckResourceAttr(resourceName, "remove_upon_expiry", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "confirmation_sent_at"),
+					resource.TestCheckResourceAttr(resourceName, "confirmed_at", ""),
+				),
+			},
+			{
+				Config: testAccForwardingConfig(domainName, mailboxLocalPart, `
+  is_active = false
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "is_active", "false"),
+					resource.TestCheckResourceAttr(resourceName, "expires_on", ""),
+					resource.TestCheckResourceAttr(resourceName, "remove_upon_expiry", "false"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        fmt.Sprintf("%s/%s/forward@example.net", domainName, mailboxLocalPart),
+				ImportStateVerifyIdentifierAttribute: "address",
+			},
+		},
+	})
+}
+
+func TestAccForwardingResource_invalidExpiresOn(t *testing.T) {
+	testFakeSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccForwardingConfig(testFakeDomain, "tfacc-fwd", `
+  expires_on = "31.12.2099"
+`),
+				ExpectError: regexp.MustCompile(`not a valid date in YYYY-MM-DD format`),
+			},
+		},
+	})
+}
+
+func TestAccForwardingResource_waitForConfirmation(t *testing.T) {
+	server := testFakeSetup(t)
+	setFastConfirmationPolling(t)
+
+	resourceName := "migadu_forwarding.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					// Confirm the forwarding a little while after the apply
+					// starts.
+					time.AfterFunc(500*time.Millisecond, func() {
+						_ = server.ConfirmForwarding(testFakeDomain, "tfacc-fwd", "forward@example.net")
+					})
+				},
+				Config: testAccForwardingConfig(testFakeDomain, "tfacc-fwd", `
+  wait_for_confirmation = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "wait_for_confirmation", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "confirmed_at"),
+					resource.TestCheckResourceAttr(resourceName, "blocked_at", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccForwardingResource_waitForConfirmationTimeout(t *testing.T) {
+	testFakeSetup(t)
+	setFastConfirmationPolling(t)
+
+	resourceName := "migadu_forwarding.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// An unconfirmed forwarding is only warned about, so the
+				// apply succeeds once the wait times out.
+				Config: testAccForwardingConfig(testFakeDomain, "tfacc-fwd", `
+  wait_for_confirmation = true
+
+  timeouts {
+    create = "1s"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "confirmation_sent_at"),
+					resource.TestCheckResourceAttr(resourceName, "confirmed_at", ""),
+				),
+			},
+		},
+	})
+}
+
+// setFastConfirmationPolling shortens the wait_for_confirmation poll interval
+// for the duration of a test.
+func setFastConfirmationPolling(t *testing.T) {
+	t.Helper()
+
+	previous := confirmationPollInterval
+	confirmationPollInterval = 50 * time.Millisecond
+	t.Cleanup(func() { confirmationPollInterval = previous })
+}
+
+func testAccForwardingConfig(domainName, mailboxLocalPart, extra string) string {
+	return fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name             = "%[1]s"
+  local_part              = "%[2]s"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
+}
+
+resource "migadu_forwarding" "test" {
+  domain_name = migadu_mailbox.test.domain_name
+  mailbox     = migadu_mailbox.test.local_part
+  address     = "forward@example.net"
+%[3]s}
+`, domainName, mailboxLocalPart, extra)
+}
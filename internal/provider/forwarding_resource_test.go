@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestNewForwardingResourceMetadata(t *testing.T) {
+	r := NewForwardingResource()
+
+	var resp resource.MetadataResponse
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "migadu"}, &resp)
+
+	if resp.TypeName != "migadu_forwarding" {
+		t.Fatalf("expected type name %q, got %q", "migadu_forwarding", resp.TypeName)
+	}
+}
+
+func TestForwardingResourceImportState(t *testing.T) {
+	r := NewForwardingResource()
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		t.Fatal("expected forwarding resource to implement ResourceWithImportState")
+	}
+	schemaResp := mustResourceSchema(t, r)
+
+	testCases := map[string]string{
+		"valid":     "example.com/alice/alice@example.org",
+		"addresses": "Alice@Example.com/alice@example.org",
+	}
+
+	for name, id := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := resource.ImportStateResponse{
+				State: newStateForSchema(schemaResp.Schema),
+			}
+
+			importer.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected import errors: %v", resp.Diagnostics)
+			}
+
+			if got := getStateStringAttribute(t, resp.State, "domain_name"); got != "example.com" {
+				t.Fatalf("expected domain_name to be %q, got %q", "example.com", got)
+			}
+
+			if got := getStateStringAttribute(t, resp.State, "mailbox"); got != "alice" {
+				t.Fatalf("expected mailbox to be %q, got %q", "alice", got)
+			}
+
+			if got := getStateStringAttribute(t, resp.State, "address"); got != "alice@example.org" {
+				t.Fatalf("expected address to be %q, got %q", "alice@example.org", got)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "example.com/alice"}, &resp)
+
+		assertHasDiagnosticSummary(t, resp.Diagnostics, "Invalid Import ID")
+	})
+}
@@ -22,9 +22,9 @@ type ForwardingsDataSource struct {
 }
 
 type ForwardingsDataSourceModel struct {
-	DomainName  types.String `tfsdk:"domain_name"`
-	Mailbox     types.String `tfsdk:"mailbox"`
-	Forwardings types.List   `tfsdk:"forwardings"`
+	DomainName  DomainNameValue `tfsdk:"domain_name"`
+	Mailbox     LocalPartValue  `tfsdk:"mailbox"`
+	Forwardings types.List      `tfsdk:"forwardings"`
 }
 
 type ForwardingListItemModel struct {
@@ -47,10 +47,12 @@ func (d *ForwardingsDataSource) Schema(ctx context.Context, req datasource.Schem
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"mailbox": schema.StringAttribute{
 				MarkdownDescription: "The mailbox local part.",
+				CustomType:          LocalPartType{},
 				Required:            true,
 			},
 			"forwardings": schema.ListNestedAttribute{
@@ -96,10 +98,10 @@ func (d *ForwardingsDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	forwardings, err := d.client.ListForwardings(ctx, domain, &migadu.Mailbox{LocalPart: data.Mailbox.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list forwardings, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "list forwardings", err)
 		return
 	}
 
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewForwardingsDataSourceMetadata(t *testing.T) {
@@ -43,3 +46,25 @@ func TestNewForwardingsDataSourceSchemaExpectations(t *testing.T) {
 	requireDataSourceStringAttribute(t, nestedAttrs, "expires_on", false, true)
 	requireDataSourceBoolAttributeComputed(t, nestedAttrs, "is_active")
 }
+
+func TestAccForwardingsDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	localPart := fmt.Sprintf("tfacc-forwardings-ds-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailboxConfig(domainName, localPart) + `
+data "migadu_forwardings" "test" {
+  domain_name = migadu_mailbox.test.domain_name
+  mailbox     = migadu_mailbox.test.local_part
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_forwardings.test", "forwardings.#", "0"),
+				),
+			},
+		},
+	})
+}
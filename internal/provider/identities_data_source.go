@@ -22,9 +22,9 @@ type IdentitiesDataSource struct {
 }
 
 type IdentitiesDataSourceModel struct {
-	DomainName types.String `tfsdk:"domain_name"`
-	Mailbox    types.String `tfsdk:"mailbox"`
-	Identities types.List   `tfsdk:"identities"`
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	Mailbox    LocalPartValue  `tfsdk:"mailbox"`
+	Identities types.List      `tfsdk:"identities"`
 }
 
 type IdentityListItemModel struct {
@@ -48,10 +48,12 @@ func (d *IdentitiesDataSource) Schema(ctx context.Context, req datasource.Schema
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"mailbox": schema.StringAttribute{
 				MarkdownDescription: "The mailbox local part.",
+				CustomType:          LocalPartType{},
 				Required:            true,
 			},
 			"identities": schema.ListNestedAttribute{
@@ -110,10 +112,10 @@ func (d *IdentitiesDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	identities, err := d.client.ListIdentities(ctx, domain, &migadu.Mailbox{LocalPart: data.Mailbox.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list identities, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "list identities", err)
 		return
 	}
 
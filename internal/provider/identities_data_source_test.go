@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewIdentitiesDataSourceMetadata(t *testing.T) {
@@ -43,3 +46,28 @@ func TestNewIdentitiesDataSourceSchemaExpectations(t *testing.T) {
 	requireDataSourceStringAttribute(t, nestedAttrs, "address", false, true)
 	requireDataSourceBoolAttributeComputed(t, nestedAttrs, "may_send")
 }
+
+func TestAccIdentitiesDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	suffix := time.Now().UnixNano()
+	mailboxLocalPart := fmt.Sprintf("tfacc-identity-owner-%d", suffix)
+	identityLocalPart := fmt.Sprintf("tfacc-identity-ds-%d", suffix)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityConfig(domainName, mailboxLocalPart, identityLocalPart) + `
+data "migadu_identities" "test" {
+  domain_name = migadu_identity.test.domain_name
+  mailbox     = migadu_identity.test.mailbox
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_identities.test", "identities.#", "1"),
+					resource.TestCheckResourceAttr("data.migadu_identities.test", "identities.0.local_part", identityLocalPart),
+				),
+			},
+		},
+	})
+}
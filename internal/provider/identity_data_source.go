@@ -21,16 +21,16 @@ type IdentityDataSource struct {
 }
 
 type IdentityDataSourceModel struct {
-	DomainName           types.String `tfsdk:"domain_name"`
-	Mailbox              types.String `tfsdk:"mailbox"`
-	LocalPart            types.String `tfsdk:"local_part"`
-	Name                 types.String `tfsdk:"name"`
-	Address              types.String `tfsdk:"address"`
-	MaySend              types.Bool   `tfsdk:"may_send"`
-	MayReceive           types.Bool   `tfsdk:"may_receive"`
-	MayAccessImap        types.Bool   `tfsdk:"may_access_imap"`
-	MayAccessPop3        types.Bool   `tfsdk:"may_access_pop3"`
-	MayAccessManageSieve types.Bool   `tfsdk:"may_access_managesieve"`
+	DomainName           DomainNameValue `tfsdk:"domain_name"`
+	Mailbox              LocalPartValue  `tfsdk:"mailbox"`
+	LocalPart            LocalPartValue  `tfsdk:"local_part"`
+	Name                 types.String    `tfsdk:"name"`
+	Address              types.String    `tfsdk:"address"`
+	MaySend              types.Bool      `tfsdk:"may_send"`
+	MayReceive           types.Bool      `tfsdk:"may_receive"`
+	MayAccessImap        types.Bool      `tfsdk:"may_access_imap"`
+	MayAccessPop3        types.Bool      `tfsdk:"may_access_pop3"`
+	MayAccessManageSieve types.Bool      `tfsdk:"may_access_managesieve"`
 }
 
 func (d *IdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -44,14 +44,17 @@ func (d *IdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"mailbox": schema.StringAttribute{
 				MarkdownDescription: "The mailbox local part this identity belongs to.",
+				CustomType:          LocalPartType{},
 				Required:            true,
 			},
 			"local_part": schema.StringAttribute{
 				MarkdownDescription: "The local part of the identity address.",
+				CustomType:          LocalPartType{},
 				Required:            true,
 			},
 			"name": schema.StringAttribute{
@@ -110,13 +113,13 @@ func (d *IdentityDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	mailboxStr := data.Mailbox.ValueString()
 	localPart := data.LocalPart.ValueString()
 
 	identity, err := d.client.GetIdentity(ctx, domain, &migadu.Mailbox{LocalPart: mailboxStr}, &migadu.Identity{LocalPart: localPart})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read identity, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "read identity", err)
 		return
 	}
 
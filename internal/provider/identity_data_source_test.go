@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewIdentityDataSourceMetadata(t *testing.T) {
@@ -40,3 +43,29 @@ func TestNewIdentityDataSourceSchemaExpectations(t *testing.T) {
 	requireDataSourceStringAttribute(t, attrs, "address", false, true)
 	requireDataSourceBoolAttributeComputed(t, attrs, "may_send")
 }
+
+func TestAccIdentityDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	suffix := time.Now().UnixNano()
+	mailboxLocalPart := fmt.Sprintf("tfacc-identity-owner-%d", suffix)
+	identityLocalPart := fmt.Sprintf("tfacc-identity-ds-%d", suffix)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityConfig(domainName, mailboxLocalPart, identityLocalPart) + `
+data "migadu_identity" "test" {
+  domain_name = migadu_identity.test.domain_name
+  mailbox     = migadu_identity.test.mailbox
+  local_part  = migadu_identity.test.local_part
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_identity.test", "address", identityLocalPart+"@"+domainName),
+					resource.TestCheckResourceAttr("data.migadu_identity.test", "name", "Terraform Acceptance Identity"),
+				),
+			},
+		},
+	})
+}
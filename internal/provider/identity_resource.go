@@ -6,18 +6,26 @@ import (
 	"strings"
 
 	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &IdentityResource{}
 var _ resource.ResourceWithImportState = &IdentityResource{}
+var _ resource.ResourceWithIdentity = &IdentityResource{}
+var _ resource.ResourceWithMoveState = &IdentityResource{}
+var _ resource.ResourceWithConfigValidators = &IdentityResource{}
 
 func NewIdentityResource() resource.Resource {
 	return &IdentityResource{}
@@ -28,17 +36,28 @@ type IdentityResource struct {
 }
 
 type IdentityResourceModel struct {
-	DomainName           types.String `tfsdk:"domain_name"`
-	Mailbox              types.String `tfsdk:"mailbox"`
-	LocalPart            types.String `tfsdk:"local_part"`
-	Name                 types.String `tfsdk:"name"`
-	Password             types.String `tfsdk:"password"`
-	MaySend              types.Bool   `tfsdk:"may_send"`
-	MayReceive           types.Bool   `tfsdk:"may_receive"`
-	MayAccessImap        types.Bool   `tfsdk:"may_access_imap"`
-	MayAccessPop3        types.Bool   `tfsdk:"may_access_pop3"`
-	MayAccessManageSieve types.Bool   `tfsdk:"may_access_managesieve"`
-	Address              types.String `tfsdk:"address"`
+	DomainName           DomainNameValue   `tfsdk:"domain_name"`
+	Mailbox              LocalPartValue    `tfsdk:"mailbox"`
+	LocalPart            LocalPartValue    `tfsdk:"local_part"`
+	Name                 types.String      `tfsdk:"name"`
+	Password             types.String      `tfsdk:"password"`
+	PasswordWO           types.String      `tfsdk:"password_wo"`
+	PasswordWOVersion    types.Int64       `tfsdk:"password_wo_version"`
+	MaySend              types.Bool        `tfsdk:"may_send"`
+	MayReceive           types.Bool        `tfsdk:"may_receive"`
+	MayAccessImap        types.Bool        `tfsdk:"may_access_imap"`
+	MayAccessPop3        types.Bool        `tfsdk:"may_access_pop3"`
+	MayAccessManageSieve types.Bool        `tfsdk:"may_access_managesieve"`
+	Address              EmailAddressValue `tfsdk:"address"`
+	AdoptExisting        types.Bool        `tfsdk:"adopt_existing"`
+	Timeouts             timeouts.Value    `tfsdk:"timeouts"`
+}
+
+// IdentityResourceIdentityModel describes the resource identity data model.
+type IdentityResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	Mailbox    types.String `tfsdk:"mailbox"`
+	LocalPart  types.String `tfsdk:"local_part"`
 }
 
 func (r *IdentityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,23 +70,30 @@ func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaReques
 
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
-				MarkdownDescription: "The domain name.",
-				Required:            true,
+				MarkdownDescription: "The domain name. Required unless `address` is set.",
+				CustomType:          DomainNameType{},
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					addressPart("domain_name"),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"mailbox": schema.StringAttribute{
 				MarkdownDescription: "The mailbox local part this identity belongs to.",
+				CustomType:          LocalPartType{},
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"local_part": schema.StringAttribute{
-				MarkdownDescription: "The local part of the identity address.",
-				Required:            true,
+				MarkdownDescription: "The local part of the identity address. Required unless `address` is set.",
+				CustomType:          LocalPartType{},
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					addressPart("local_part"),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -78,9 +104,30 @@ func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaReques
 				Default:             stringdefault.StaticString(""),
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password for the identity.",
+				MarkdownDescription: "Password for the identity. Stored in state; prefer `password_wo`.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password_wo")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only password for the identity, never stored in state. Requires Terraform 1.11 or later. " +
+					"Sent on create and whenever `password_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+					stringvalidator.AlsoRequires(path.MatchRoot("password_wo_version")),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `password_wo`. Change this value to send a new `password_wo` to Migadu.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
 			},
 			"may_send": schema.BoolAttribute{
 				MarkdownDescription: "Whether the identity can send emails.",
@@ -113,13 +160,63 @@ func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaReques
 				Default:             booldefault.StaticBool(true),
 			},
 			"address": schema.StringAttribute{
-				MarkdownDescription: "Full email address (computed).",
+				MarkdownDescription: "Full email address of the identity, known during plan. Can be set instead of `domain_name` and `local_part`, which are then derived from it in lowercase.",
+				Optional:            true,
 				Computed:            true,
+				CustomType:          EmailAddressType{},
+				PlanModifiers: []planmodifier.String{
+					addressFromParts(),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over an existing identity when creating this resource finds one already there, instead of failing. " +
+					"The existing identity is updated to match this configuration. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *IdentityResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the identity.",
+				RequiredForImport: true,
+			},
+			"mailbox": identityschema.StringAttribute{
+				Description:       "The local part of the mailbox the identity belongs to.",
+				RequiredForImport: true,
+			},
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the identity address (before the @).",
+				RequiredForImport: true,
 			},
 		},
 	}
 }
 
+func (r *IdentityResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromMetio("migadu_identity", metioIdentitySchema(), []string{"domain_name", "mailbox", "local_part"}, moveIdentityFromMetio),
+	}
+}
+
+func (r *IdentityResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return addressConfigValidators()
+}
+
 func (r *IdentityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -144,6 +241,22 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Write-only values are only available from the configuration.
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	identity := &migadu.Identity{
 		LocalPart:            data.LocalPart.ValueString(),
 		Name:                 data.Name.ValueString(),
@@ -154,18 +267,34 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 		MayAccessPop3:        data.MayAccessPop3.ValueBool(),
 		MayAccessManagesieve: data.MayAccessManageSieve.ValueBool(),
 	}
+	if !passwordWO.IsNull() {
+		identity.Password = passwordWO.ValueString()
+	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	mailboxStr := data.Mailbox.ValueString()
 
-	created, err := r.client.NewIdentity(ctx, domain, &migadu.Mailbox{LocalPart: mailboxStr}, identity)
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	mailbox := &migadu.Mailbox{LocalPart: mailboxStr}
+	created, err := createOrAdopt(ctx, data.AdoptExisting.ValueBool(), identity.LocalPart+"@"+domain.Name,
+		func() (*migadu.Identity, error) { return r.client.NewIdentity(ctx, domain, mailbox, identity) },
+		func() (*migadu.Identity, error) { return r.client.GetIdentity(ctx, domain, mailbox, identity) },
+		func() (*migadu.Identity, error) { return r.client.UpdateIdentity(ctx, domain, mailbox, identity) },
+	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create identity, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "create identity", err)
 		return
 	}
 
-	data.Address = types.StringValue(created.Address)
+	data.Address = appliedAddress(&resp.Diagnostics, data.Address, created.Address)
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, IdentityResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Mailbox:    data.Mailbox.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -176,13 +305,32 @@ func (r *IdentityResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, IdentityResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Mailbox:    data.Mailbox.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	mailboxStr := data.Mailbox.ValueString()
 	localPart := data.LocalPart.ValueString()
 
 	identity, err := r.client.GetIdentity(ctx, domain, &migadu.Mailbox{LocalPart: mailboxStr}, &migadu.Identity{LocalPart: localPart})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read identity, got error: %s", err))
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read identity", err)
 		return
 	}
 
@@ -192,7 +340,12 @@ func (r *IdentityResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.MayAccessImap = types.BoolValue(identity.MayAccessImap)
 	data.MayAccessPop3 = types.BoolValue(identity.MayAccessPop3)
 	data.MayAccessManageSieve = types.BoolValue(identity.MayAccessManagesieve)
-	data.Address = types.StringValue(identity.Address)
+	data.Address = stateAddress(data.Address, identity.Address)
+
+	// adopt_existing only affects create; imported resources take the default.
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -204,6 +357,23 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var passwordWO types.String
+	var priorPasswordWOVersion types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password_wo_version"), &priorPasswordWOVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	identity := &migadu.Identity{
 		LocalPart:            data.LocalPart.ValueString(),
 		Name:                 data.Name.ValueString(),
@@ -214,18 +384,30 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 		MayAccessPop3:        data.MayAccessPop3.ValueBool(),
 		MayAccessManagesieve: data.MayAccessManageSieve.ValueBool(),
 	}
+	// The write-only password is only resent when its version changes.
+	if !passwordWO.IsNull() && !data.PasswordWOVersion.Equal(priorPasswordWOVersion) {
+		identity.Password = passwordWO.ValueString()
+	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	mailboxStr := data.Mailbox.ValueString()
 
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
 	updated, err := r.client.UpdateIdentity(ctx, domain, &migadu.Mailbox{LocalPart: mailboxStr}, identity)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update identity, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "update identity", err)
 		return
 	}
 
-	data.Address = types.StringValue(updated.Address)
+	data.Address = appliedAddress(&resp.Diagnostics, data.Address, updated.Address)
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, IdentityResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Mailbox:    data.Mailbox.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -236,31 +418,63 @@ func (r *IdentityResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	mailboxStr := data.Mailbox.ValueString()
 	identity := &migadu.Identity{
 		LocalPart: data.LocalPart.ValueString(),
 	}
 
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
 	err := r.client.DeleteIdentity(ctx, domain, &migadu.Mailbox{LocalPart: mailboxStr}, identity)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete identity, got error: %s", err))
+	// The identity may already be gone, such as when its mailbox was deleted
+	// first.
+	if err != nil && !isNotFoundError(err) {
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "delete identity", err)
 		return
 	}
 }
 
 func (r *IdentityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Format: domain_name/mailbox/local_part
-	parts := strings.Split(req.ID, "/")
-	if len(parts) != 3 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Import ID must be in format: domain_name/mailbox/local_part",
-		)
+	// Format: domain_name/mailbox/local_part or mailbox_address/identity_address
+	if strings.Contains(req.ID, "@") {
+		mailboxAddress, identityAddress, _ := strings.Cut(req.ID, "/")
+
+		mailbox, mailboxDomain, err := splitAddress(mailboxAddress)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+
+		localPart, domain, err := splitAddress(identityAddress)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+
+		if domainKey(domain) != domainKey(mailboxDomain) {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("The identity %s must be on the same domain as its mailbox %s.", identityAddress, mailboxAddress),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domain)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mailbox"), mailbox)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mailbox"), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("local_part"), parts[2])...)
+	importStateByKey(ctx, req, resp, "domain_name", "mailbox", "local_part")
 }
@@ -2,40 +2,38 @@ package provider
 
 import (
 	"fmt"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccIdentityResource_basic(t *testing.T) {
-	if os.Getenv("TF_ACC") == "" {
-		t.Skip("set TF_ACC=1 to run acceptance tests")
-	}
+	domainName := testAccSetup(t)
 
 	resourceName := "migadu_identity.test"
 	mailboxLocalPart := fmt.Sprintf("tfacc-mbx-%d", time.Now().UnixNano())
 	identityLocalPart := fmt.Sprintf("tfacc-id-%d", time.Now().UnixNano())
-	importID := fmt.Sprintf("%s/%s/%s", os.Getenv("MIGADU_TEST_DOMAIN"), mailboxLocalPart, identityLocalPart)
+	importID := fmt.Sprintf("%s/%s/%s", domainName, mailboxLocalPart, identityLocalPart)
 
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccIdentityConfig(mailboxLocalPart, identityLocalPart),
+				Config: testAccIdentityConfig(domainName, mailboxLocalPart, identityLocalPart),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "domain_name", os.Getenv("MIGADU_TEST_DOMAIN")),
+					resource.TestCheckResourceAttr(resourceName, "domain_name", domainName),
 					resource.TestCheckResourceAttr(resourceName, "mailbox", mailboxLocalPart),
 					resource.TestCheckResourceAttr(resourceName, "local_part", identityLocalPart),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateId:     importID,
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        importID,
+				ImportStateVerifyIdentifierAttribute: "address",
 				// password is write-only; exclude from import verification
 				ImportStateVerifyIgnore: []string{"password"},
 			},
@@ -43,13 +41,52 @@ func TestAccIdentityResource_basic(t *testing.T) {
 	})
 }
 
-func testAccIdentityConfig(mailboxLocalPart, identityLocalPart string) string {
+func TestAccIdentityResource_address(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_identity.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "owner" {
+  address                 = "tfacc-owner@%[1]s"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
+}
+
+resource "migadu_identity" "test" {
+  address = "tfacc-sender@%[1]s"
+  mailbox = migadu_mailbox.owner.local_part
+  name    = "Sender"
+}
+`, testFakeDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "domain_name", testFakeDomain),
+					resource.TestCheckResourceAttr(resourceName, "mailbox", "tfacc-owner"),
+					resource.TestCheckResourceAttr(resourceName, "local_part", "tfacc-sender"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        fmt.Sprintf("tfacc-owner@%[1]s/tfacc-sender@%[1]s", testFakeDomain),
+				ImportStateVerifyIdentifierAttribute: "address",
+			},
+		},
+	})
+}
+
+func testAccIdentityConfig(domainName, mailboxLocalPart, identityLocalPart string) string {
 	return fmt.Sprintf(`
 resource "migadu_mailbox" "owner" {
-  domain_name     = "%s"
-  local_part      = "%s"
-  name            = "Terraform Acceptance Owner %s"
-  password_method = "invitation"
+  domain_name             = "%s"
+  local_part              = "%s"
+  name                    = "Terraform Acceptance Owner %s"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
 }
 
 resource "migadu_identity" "test" {
@@ -58,6 +95,54 @@ resource "migadu_identity" "test" {
   local_part  = "%s"
   name        = "Terraform Acceptance Identity"
 }
-`, os.Getenv("MIGADU_TEST_DOMAIN"), mailboxLocalPart, mailboxLocalPart,
-		os.Getenv("MIGADU_TEST_DOMAIN"), identityLocalPart)
+`, domainName, mailboxLocalPart, mailboxLocalPart, domainName, identityLocalPart)
+}
+
+func TestAccIdentityResource_passwordWO(t *testing.T) {
+	server := testFakeSetup(t)
+	resourceName := "migadu_identity.test"
+
+	checkPassword := func(expected string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			password, ok := server.IdentityPassword(testFakeDomain, "tfacc-identity-wo-owner", "tfacc-identity-wo")
+			if !ok {
+				return fmt.Errorf("identity not found")
+			}
+			if password != expected {
+				return fmt.Errorf("expected Migadu to hold password %q, got %q", expected, password)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityPasswordWOConfig("first-secret", 1),
+				Check: resource.ComposeTestCheckFunc(
+					checkPassword("first-secret"),
+					resource.TestCheckNoResourceAttr(resourceName, "password_wo"),
+					resource.TestCheckNoResourceAttr(resourceName, "password"),
+				),
+			},
+			{
+				Config: testAccIdentityPasswordWOConfig("second-secret", 2),
+				Check:  checkPassword("second-secret"),
+			},
+		},
+	})
+}
+
+func testAccIdentityPasswordWOConfig(password string, version int) string {
+	return testAccMailboxConfig(testFakeDomain, "tfacc-identity-wo-owner") + fmt.Sprintf(`
+resource "migadu_identity" "test" {
+  domain_name         = "%s"
+  mailbox             = migadu_mailbox.test.local_part
+  local_part          = "tfacc-identity-wo"
+  name                = "Terraform Acceptance Identity"
+  password_wo         = "%s"
+  password_wo_version = %d
+}
+`, testFakeDomain, password, version)
 }
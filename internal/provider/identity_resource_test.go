@@ -61,6 +61,50 @@ func TestIdentityResourceImportState(t *testing.T) {
 		}
 	})
 
+	t.Run("addresses", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "mailbox@example.com/Admin@Example.com"}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected import errors: %v", resp.Diagnostics)
+		}
+
+		if got := getStateStringAttribute(t, resp.State, "domain_name"); got != "example.com" {
+			t.Fatalf("expected domain_name to be %q, got %q", "example.com", got)
+		}
+
+		if got := getStateStringAttribute(t, resp.State, "mailbox"); got != "mailbox" {
+			t.Fatalf("expected mailbox to be %q, got %q", "mailbox", got)
+		}
+
+		if got := getStateStringAttribute(t, resp.State, "local_part"); got != "admin" {
+			t.Fatalf("expected local_part to be %q, got %q", "admin", got)
+		}
+	})
+
+	t.Run("addresses on different domains", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "mailbox@example.com/admin@example.org"}, &resp)
+
+		assertHasDiagnosticSummary(t, resp.Diagnostics, "Invalid Import ID")
+	})
+
+	t.Run("missing identity address", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "mailbox@example.com"}, &resp)
+
+		assertHasDiagnosticSummary(t, resp.Diagnostics, "Invalid Import ID")
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		resp := resource.ImportStateResponse{
 			State: newStateForSchema(schemaResp.Schema),
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/idna"
+)
+
+// domainToASCII converts a domain name to its lowercase ASCII form, with
+// non-ASCII labels encoded as punycode following IDNA2008. The Migadu API
+// only accepts domains in this form.
+func domainToASCII(name string) (string, error) {
+	return idna.Lookup.ToASCII(name)
+}
+
+// domainToUnicode converts a domain name to its lowercase Unicode form,
+// decoding punycode labels.
+func domainToUnicode(name string) (string, error) {
+	return idna.Lookup.ToUnicode(name)
+}
+
+// domainKey returns the form in which two domain names are compared. Names
+// that can't be converted are compared in lowercase, and are reported by
+// validation.
+func domainKey(name string) string {
+	ascii, err := domainToASCII(name)
+	if err != nil {
+		return strings.ToLower(name)
+	}
+	return ascii
+}
+
+// addressToASCII converts the domain part of an email address to its ASCII
+// form and lowercases the local part. A value without an @, such as a domain
+// in an allow or deny list, is converted as a domain name. Addresses without
+// a valid domain are only lowercased.
+func addressToASCII(address string) string {
+	localPart, domain, found := strings.Cut(address, "@")
+	if !found {
+		return domainKey(address)
+	}
+	return strings.ToLower(localPart) + "@" + domainKey(domain)
+}
+
+// addressesToASCII applies addressToASCII to each address or domain, for
+// lists sent to the Migadu API.
+func addressesToASCII(addresses []string) []string {
+	if addresses == nil {
+		return nil
+	}
+
+	converted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		converted = append(converted, addressToASCII(address))
+	}
+	return converted
+}
+
+// domainNameForms returns the ASCII and Unicode forms of a domain name, for
+// the name_ascii and name_unicode attributes. A name that can't be converted
+// is returned as is in both.
+func domainNameForms(name string) (ascii, unicode types.String) {
+	asciiName, err := domainToASCII(name)
+	if err != nil {
+		return types.StringValue(name), types.StringValue(name)
+	}
+
+	unicodeName, err := domainToUnicode(asciiName)
+	if err != nil {
+		unicodeName = asciiName
+	}
+	return types.StringValue(asciiName), types.StringValue(unicodeName)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDomainNameForms(t *testing.T) {
+	testCases := map[string]struct {
+		name            string
+		expectedASCII   string
+		expectedUnicode string
+	}{
+		"ascii":          {name: "example.com", expectedASCII: "example.com", expectedUnicode: "example.com"},
+		"uppercase":      {name: "Example.COM", expectedASCII: "example.com", expectedUnicode: "example.com"},
+		"unicode":        {name: "bücher.example", expectedASCII: "xn--bcher-kva.example", expectedUnicode: "bücher.example"},
+		"punycode":       {name: "xn--bcher-kva.example", expectedASCII: "xn--bcher-kva.example", expectedUnicode: "bücher.example"},
+		"unicode upper":  {name: "BÜCHER.example", expectedASCII: "xn--bcher-kva.example", expectedUnicode: "bücher.example"},
+		"invalid domain": {name: "ex_ample.com", expectedASCII: "ex_ample.com", expectedUnicode: "ex_ample.com"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ascii, unicode := domainNameForms(tc.name)
+			if ascii.ValueString() != tc.expectedASCII {
+				t.Fatalf("expected ASCII form %q, got %q", tc.expectedASCII, ascii.ValueString())
+			}
+			if unicode.ValueString() != tc.expectedUnicode {
+				t.Fatalf("expected Unicode form %q, got %q", tc.expectedUnicode, unicode.ValueString())
+			}
+		})
+	}
+}
+
+func TestAddressesToASCII(t *testing.T) {
+	got := addressesToASCII([]string{"Amy@Bücher.example", "bob@example.com", "Bücher.example", ""})
+	expected := []string{"amy@xn--bcher-kva.example", "bob@example.com", "xn--bcher-kva.example", ""}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	if addressesToASCII(nil) != nil {
+		t.Fatal("expected nil to stay nil")
+	}
+}
@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &MailboxAutoresponderResource{}
+var _ resource.ResourceWithImportState = &MailboxAutoresponderResource{}
+var _ resource.ResourceWithIdentity = &MailboxAutoresponderResource{}
+
+func NewMailboxAutoresponderResource() resource.Resource {
+	return &MailboxAutoresponderResource{}
+}
+
+type MailboxAutoresponderResource struct {
+	client *migadu.Client
+}
+
+type MailboxAutoresponderResourceModel struct {
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	LocalPart  LocalPartValue  `tfsdk:"local_part"`
+	Active     types.Bool      `tfsdk:"active"`
+	Subject    types.String    `tfsdk:"subject"`
+	Body       types.String    `tfsdk:"body"`
+	ExpiresOn  types.String    `tfsdk:"expires_on"`
+	Timeouts   timeouts.Value  `tfsdk:"timeouts"`
+}
+
+// MailboxAutoresponderResourceIdentityModel describes the resource identity
+// data model.
+type MailboxAutoresponderResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	LocalPart  types.String `tfsdk:"local_part"`
+}
+
+func (r *MailboxAutoresponderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mailbox_autoresponder"
+}
+
+func (r *MailboxAutoresponderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the autoresponder (vacation message) of a Migadu mailbox, separately from the mailbox itself.\n\n" +
+			"-> **Note:** Destroying this resource turns the autoresponder off and clears its message. It does not delete the mailbox.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				MarkdownDescription: "The domain name of the mailbox.",
+				CustomType:          DomainNameType{},
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				MarkdownDescription: "The local part of the mailbox.",
+				CustomType:          LocalPartType{},
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the autoresponder replies to incoming mail. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "Subject of the automatic reply.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "Body of the automatic reply.",
+				Required:            true,
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "Date in `YYYY-MM-DD` format after which the autoresponder stops replying. Empty for no expiry.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					isDate(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *MailboxAutoresponderResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the mailbox.",
+				RequiredForImport: true,
+			},
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the mailbox.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *MailboxAutoresponderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*migadu.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *migadu.Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MailboxAutoresponderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MailboxAutoresponderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if err := r.setAutoresponder(ctx, data, data.autoresponder()); err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "create mailbox autoresponder", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MailboxAutoresponderResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MailboxAutoresponderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MailboxAutoresponderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MailboxAutoresponderResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+	mailbox, err := r.client.GetMailbox(ctx, domain, &migadu.Mailbox{LocalPart: data.LocalPart.ValueString()})
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read mailbox", err)
+		return
+	}
+
+	data.Active = types.BoolValue(mailbox.AutorespondActive)
+	data.Subject = types.StringValue(mailbox.AutorespondSubject)
+	data.Body = types.StringValue(mailbox.AutorespondBody)
+	data.ExpiresOn = types.StringValue(mailbox.AutorespondExpiresOn)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MailboxAutoresponderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data MailboxAutoresponderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if err := r.setAutoresponder(ctx, data, data.autoresponder()); err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "update mailbox autoresponder", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MailboxAutoresponderResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MailboxAutoresponderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data MailboxAutoresponderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// The autoresponder goes away with its mailbox, so there is nothing left
+	// to turn off if the mailbox is already gone.
+	err := r.setAutoresponder(ctx, data, &migadu.Mailbox{})
+	if err != nil && !isNotFoundError(err) {
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "delete mailbox autoresponder", err)
+		return
+	}
+}
+
+func (r *MailboxAutoresponderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Format: domain_name/local_part or the mailbox address
+	if strings.Contains(req.ID, "@") {
+		importStateByAddress(ctx, req.ID, resp)
+		return
+	}
+
+	importStateByKey(ctx, req, resp, "domain_name", "local_part")
+}
+
+// setAutoresponder replaces the autoresponder settings of the mailbox with
+// those of autoresponder. The Migadu API only updates whole mailboxes, so
+// the mailbox is read first to send its other settings back unchanged.
+func (r *MailboxAutoresponderResource) setAutoresponder(ctx context.Context, data MailboxAutoresponderResourceModel, autoresponder *migadu.Mailbox) error {
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	mailbox, err := r.client.GetMailbox(ctx, domain, &migadu.Mailbox{LocalPart: data.LocalPart.ValueString()})
+	if err != nil {
+		return err
+	}
+
+	copyAutoresponder(mailbox, autoresponder)
+	_, err = r.client.UpdateMailbox(ctx, domain, mailbox)
+	return err
+}
+
+// autoresponder returns a mailbox holding only the planned autoresponder
+// settings.
+func (m MailboxAutoresponderResourceModel) autoresponder() *migadu.Mailbox {
+	return &migadu.Mailbox{
+		AutorespondActive:    m.Active.ValueBool(),
+		AutorespondSubject:   m.Subject.ValueString(),
+		AutorespondBody:      m.Body.ValueString(),
+		AutorespondExpiresOn: m.ExpiresOn.ValueString(),
+	}
+}
+
+// copyAutoresponder copies the autoresponder settings of src to dst.
+func copyAutoresponder(dst, src *migadu.Mailbox) {
+	dst.AutorespondActive = src.AutorespondActive
+	dst.AutorespondSubject = src.AutorespondSubject
+	dst.AutorespondBody = src.AutorespondBody
+	dst.AutorespondExpiresOn = src.AutorespondExpiresOn
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccMailboxAutoresponderResource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resourceName := "migadu_mailbox_autoresponder.test"
+	localPart := fmt.Sprintf("tfacc-ar-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailboxAutoresponderConfig(domainName, localPart, "Mailbox", `
+resource "migadu_mailbox_autoresponder" "test" {
+  domain_name = migadu_mailbox.test.domain_name
+  local_part  = migadu_mailbox.test.local_part
+  subject     = "Out of office"
+  body        = "Back on Monday."
+  expires_on  = "2099-12-31"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "domain_name", domainName),
+					resource.TestCheckResourceAttr(resourceName, "local_part", localPart),
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "subject", "Out of office"),
+					resource.TestCheckResourceAttr(resourceName, "body", "Back on Monday."),
+					resource.TestCheckResourceAttr(resourceName, "expires_on", "2099-12-31"),
+				),
+			},
+			{
+				// Updating the mailbox keeps the autoresponder it doesn't
+				// manage, so neither resource plans a change afterwards.
+				Config: testAccMailboxAutoresponderConfig(domainName, localPart, "Renamed Mailbox", `
+resource "migadu_mailbox_autoresponder" "test" {
+  domain_name = migadu_mailbox.test.domain_name
+  local_part  = migadu_mailbox.test.local_part
+  subject     = "Out of office"
+  body        = "Back on Monday."
+  expires_on  = "2099-12-31"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "name", "Renamed Mailbox"),
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+				),
+			},
+			{
+				Config: testAccMailboxAutoresponderConfig(domainName, localPart, "Renamed Mailbox", `
+resource "migadu_mailbox_autoresponder" "test" {
+  domain_name = migadu_mailbox.test.domain_name
+  local_part  = migadu_mailbox.test.local_part
+  active      = false
+  body        = "Back soon."
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "active", "false"),
+					resource.TestCheckResourceAttr(resourceName, "subject", ""),
+					resource.TestCheckResourceAttr(resourceName, "body", "Back soon."),
+					resource.TestCheckResourceAttr(resourceName, "expires_on", ""),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        fmt.Sprintf("%s@%s", localPart, domainName),
+				ImportStateVerifyIdentifierAttribute: "local_part",
+			},
+			{
+				// Destroying the autoresponder turns it off but keeps the
+				// mailbox.
+				Config: testAccMailboxAutoresponderConfig(domainName, localPart, "Renamed Mailbox", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "name", "Renamed Mailbox"),
+					testAccCheckAutoresponderOff(t, domainName, localPart),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMailboxAutoresponderResource_invalidExpiresOn(t *testing.T) {
+	testFakeSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailboxAutoresponderConfig(testFakeDomain, "tfacc-ar", "Mailbox", `
+resource "migadu_mailbox_autoresponder" "test" {
+  domain_name = migadu_mailbox.test.domain_name
+  local_part  = migadu_mailbox.test.local_part
+  body        = "Back on Monday."
+  expires_on  = "next monday"
+}
+`),
+				ExpectError: regexp.MustCompile(`not a valid date in YYYY-MM-DD format`),
+			},
+		},
+	})
+}
+
+// testAccCheckAutoresponderOff checks that the mailbox exists with its
+// autoresponder turned off and cleared.
+func testAccCheckAutoresponderOff(t *testing.T, domainName, localPart string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		mailbox, err := testAccClient(t).GetMailbox(context.Background(), &migadu.Domain{Name: domainName}, &migadu.Mailbox{LocalPart: localPart})
+		if err != nil {
+			return err
+		}
+		if mailbox.AutorespondActive || mailbox.AutorespondSubject != "" || mailbox.AutorespondBody != "" || mailbox.AutorespondExpiresOn != "" {
+			return fmt.Errorf("expected the autoresponder of %s@%s to be off and cleared, got %+v", localPart, domainName, mailbox)
+		}
+		return nil
+	}
+}
+
+func testAccMailboxAutoresponderConfig(domainName, localPart, name, autoresponder string) string {
+	return fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name             = "%s"
+  local_part              = "%s"
+  name                    = "%s"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
+}
+%s`, domainName, localPart, name, autoresponder)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestNewMailboxAutoresponderResourceMetadata(t *testing.T) {
+	r := NewMailboxAutoresponderResource()
+
+	var resp resource.MetadataResponse
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "migadu"}, &resp)
+
+	if resp.TypeName != "migadu_mailbox_autoresponder" {
+		t.Fatalf("expected type name %q, got %q", "migadu_mailbox_autoresponder", resp.TypeName)
+	}
+}
+
+func TestMailboxAutoresponderResourceImportState(t *testing.T) {
+	r := NewMailboxAutoresponderResource()
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		t.Fatal("expected mailbox autoresponder resource to implement ResourceWithImportState")
+	}
+	schemaResp := mustResourceSchema(t, r)
+
+	testCases := map[string]string{
+		"valid":   "example.com/alice",
+		"address": "Alice@Example.com",
+	}
+
+	for name, id := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := resource.ImportStateResponse{
+				State: newStateForSchema(schemaResp.Schema),
+			}
+
+			importer.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected import errors: %v", resp.Diagnostics)
+			}
+
+			if got := getStateStringAttribute(t, resp.State, "domain_name"); got != "example.com" {
+				t.Fatalf("expected domain_name to be %q, got %q", "example.com", got)
+			}
+
+			if got := getStateStringAttribute(t, resp.State, "local_part"); got != "alice" {
+				t.Fatalf("expected local_part to be %q, got %q", "alice", got)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "example.com"}, &resp)
+
+		assertHasDiagnosticSummary(t, resp.Diagnostics, "Invalid Import ID")
+	})
+}
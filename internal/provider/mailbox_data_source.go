@@ -21,22 +21,25 @@ type MailboxDataSource struct {
 }
 
 type MailboxDataSourceModel struct {
-	DomainName           types.String  `tfsdk:"domain_name"`
-	LocalPart            types.String  `tfsdk:"local_part"`
-	Name                 types.String  `tfsdk:"name"`
-	Address              types.String  `tfsdk:"address"`
-	IsInternal           types.Bool    `tfsdk:"is_internal"`
-	MaySend              types.Bool    `tfsdk:"may_send"`
-	MayReceive           types.Bool    `tfsdk:"may_receive"`
-	MayAccessImap        types.Bool    `tfsdk:"may_access_imap"`
-	MayAccessPop3        types.Bool    `tfsdk:"may_access_pop3"`
-	MayAccessManageSieve types.Bool    `tfsdk:"may_access_managesieve"`
-	StorageUsage         types.Float64 `tfsdk:"storage_usage"`
-	ChangedAt            types.String  `tfsdk:"changed_at"`
-	LastLoginAt          types.String  `tfsdk:"last_login_at"`
-	SenderAllowlist      types.List    `tfsdk:"sender_allowlist"`
-	SenderDenylist       types.List    `tfsdk:"sender_denylist"`
-	RecipientDenylist    types.List    `tfsdk:"recipient_denylist"`
+	DomainName           DomainNameValue `tfsdk:"domain_name"`
+	LocalPart            LocalPartValue  `tfsdk:"local_part"`
+	Name                 types.String    `tfsdk:"name"`
+	Address              types.String    `tfsdk:"address"`
+	IsInternal           types.Bool      `tfsdk:"is_internal"`
+	Expirable            types.Bool      `tfsdk:"expirable"`
+	ExpiresOn            types.String    `tfsdk:"expires_on"`
+	RemoveUponExpiry     types.Bool      `tfsdk:"remove_upon_expiry"`
+	MaySend              types.Bool      `tfsdk:"may_send"`
+	MayReceive           types.Bool      `tfsdk:"may_receive"`
+	MayAccessImap        types.Bool      `tfsdk:"may_access_imap"`
+	MayAccessPop3        types.Bool      `tfsdk:"may_access_pop3"`
+	MayAccessManageSieve types.Bool      `tfsdk:"may_access_managesieve"`
+	StorageUsage         types.Float64   `tfsdk:"storage_usage"`
+	ChangedAt            types.String    `tfsdk:"changed_at"`
+	LastLoginAt          types.String    `tfsdk:"last_login_at"`
+	SenderAllowlist      types.List      `tfsdk:"sender_allowlist"`
+	SenderDenylist       types.List      `tfsdk:"sender_denylist"`
+	RecipientDenylist    types.List      `tfsdk:"recipient_denylist"`
 }
 
 func (d *MailboxDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,10 +53,12 @@ func (d *MailboxDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"local_part": schema.StringAttribute{
 				MarkdownDescription: "The local part of the email address.",
+				CustomType:          LocalPartType{},
 				Required:            true,
 			},
 			"name": schema.StringAttribute{
@@ -68,6 +73,18 @@ func (d *MailboxDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				MarkdownDescription: "Whether this is an internal mailbox.",
 				Computed:            true,
 			},
+			"expirable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the mailbox expires.",
+				Computed:            true,
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "Date on which the mailbox expires.",
+				Computed:            true,
+			},
+			"remove_upon_expiry": schema.BoolAttribute{
+				MarkdownDescription: "Whether the mailbox is removed once it expires.",
+				Computed:            true,
+			},
 			"may_send": schema.BoolAttribute{
 				MarkdownDescription: "Whether the mailbox can send emails.",
 				Computed:            true,
@@ -101,19 +118,19 @@ func (d *MailboxDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:            true,
 			},
 			"sender_allowlist": schema.ListAttribute{
-				MarkdownDescription: "List of allowed sender addresses.",
+				MarkdownDescription: "List of allowed sender addresses or domains.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         AddressOrDomainType{},
 			},
 			"sender_denylist": schema.ListAttribute{
-				MarkdownDescription: "List of denied sender addresses.",
+				MarkdownDescription: "List of denied sender addresses or domains.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         AddressOrDomainType{},
 			},
 			"recipient_denylist": schema.ListAttribute{
-				MarkdownDescription: "List of denied recipient addresses.",
+				MarkdownDescription: "List of denied recipient addresses or domains.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         AddressOrDomainType{},
 			},
 		},
 	}
@@ -143,18 +160,21 @@ func (d *MailboxDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	localPart := data.LocalPart.ValueString()
 
 	mailbox, err := d.client.GetMailbox(ctx, domain, &migadu.Mailbox{LocalPart: localPart})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read mailbox, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "read mailbox", err)
 		return
 	}
 
 	data.Name = types.StringValue(mailbox.Name)
 	data.Address = types.StringValue(mailbox.Address)
 	data.IsInternal = types.BoolValue(mailbox.IsInternal)
+	data.Expirable = types.BoolValue(mailbox.Expireable)
+	data.ExpiresOn = types.StringValue(mailbox.ExpiresOn)
+	data.RemoveUponExpiry = types.BoolValue(mailbox.RemoveUponExpiry)
 	data.MaySend = types.BoolValue(mailbox.MaySend)
 	data.MayReceive = types.BoolValue(mailbox.MayReceive)
 	data.MayAccessImap = types.BoolValue(mailbox.MayAccessImap)
@@ -164,15 +184,15 @@ func (d *MailboxDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	data.ChangedAt = types.StringValue(mailbox.ChangedAt)
 	data.LastLoginAt = types.StringValue(mailbox.LastLoginAt)
 
-	senderAllowlist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.SenderAllowlist))
+	senderAllowlist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(mailbox.SenderAllowlist))
 	resp.Diagnostics.Append(diags...)
 	data.SenderAllowlist = senderAllowlist
 
-	senderDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.SenderDenylist))
+	senderDenylist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(mailbox.SenderDenylist))
 	resp.Diagnostics.Append(diags...)
 	data.SenderDenylist = senderDenylist
 
-	recipientDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.RecipientDenylist))
+	recipientDenylist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(mailbox.RecipientDenylist))
 	resp.Diagnostics.Append(diags...)
 	data.RecipientDenylist = recipientDenylist
 
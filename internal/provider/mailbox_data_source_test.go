@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewMailboxDataSourceMetadata(t *testing.T) {
@@ -48,3 +51,26 @@ func TestNewMailboxDataSourceSchemaExpectations(t *testing.T) {
 		t.Fatal("expected storage_usage to be a computed Float64Attribute")
 	}
 }
+
+func TestAccMailboxDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	localPart := fmt.Sprintf("tfacc-mailbox-ds-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailboxConfig(domainName, localPart) + `
+data "migadu_mailbox" "test" {
+  domain_name = migadu_mailbox.test.domain_name
+  local_part  = migadu_mailbox.test.local_part
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_mailbox.test", "address", localPart+"@"+domainName),
+					resource.TestCheckResourceAttrPair("data.migadu_mailbox.test", "name", "migadu_mailbox.test", "name"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultGeneratedPasswordLength = 32
+	minGeneratedPasswordLength     = 16
+	maxGeneratedPasswordLength     = 128
+
+	passwordLowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigitChars   = "0123456789"
+	passwordSpecialChars = "!#%+-.:=?@_~"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &MailboxPasswordEphemeralResource{}
+
+func NewMailboxPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &MailboxPasswordEphemeralResource{}
+}
+
+// MailboxPasswordEphemeralResource defines the ephemeral resource implementation.
+type MailboxPasswordEphemeralResource struct{}
+
+// MailboxPasswordEphemeralResourceModel describes the ephemeral resource data model.
+type MailboxPasswordEphemeralResourceModel struct {
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	LocalPart  LocalPartValue  `tfsdk:"local_part"`
+	Length     types.Int64     `tfsdk:"length"`
+	Special    types.Bool      `tfsdk:"special"`
+	Address    types.String    `tfsdk:"address"`
+	Password   types.String    `tfsdk:"password"`
+}
+
+func (r *MailboxPasswordEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mailbox_password"
+}
+
+func (r *MailboxPasswordEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a random password for a Migadu mailbox. " +
+			"The password is never stored in state or plan, so it can be passed to write-only arguments such as a secrets manager's.\n\n" +
+			"A new password is generated every time Terraform opens this ephemeral resource, which happens during both plan and apply, " +
+			"so this resource does not change the mailbox itself. To set the password on the mailbox, pass it to the `password_wo` argument of `migadu_mailbox` " +
+			"and to the secrets manager together with the same version. The mailbox and the secret are then only updated when the version changes.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				MarkdownDescription: "The domain name of the mailbox.",
+				CustomType:          DomainNameType{},
+				Required:            true,
+			},
+			"local_part": schema.StringAttribute{
+				MarkdownDescription: "The local part of the mailbox address (before the @).",
+				CustomType:          LocalPartType{},
+				Required:            true,
+			},
+			"length": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Length of the generated password, between %d and %d. Defaults to `%d`.", minGeneratedPasswordLength, maxGeneratedPasswordLength, defaultGeneratedPasswordLength),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(minGeneratedPasswordLength, maxGeneratedPasswordLength),
+				},
+			},
+			"special": schema.BoolAttribute{
+				MarkdownDescription: "Whether the generated password includes special characters (`" + passwordSpecialChars + "`). Defaults to `true`.",
+				Optional:            true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "Full email address of the mailbox.",
+				Computed:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The generated password. Always contains lowercase letters, uppercase letters and digits, and special characters unless `special` is `false`.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *MailboxPasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data MailboxPasswordEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	length := defaultGeneratedPasswordLength
+	if !data.Length.IsNull() {
+		length = int(data.Length.ValueInt64())
+	}
+
+	special := data.Special.IsNull() || data.Special.ValueBool()
+
+	password, err := generatePassword(length, special)
+	if err != nil {
+		resp.Diagnostics.AddError("Password Generation Error", fmt.Sprintf("Unable to generate mailbox password, got error: %s", err))
+		return
+	}
+
+	data.Address = types.StringValue(strings.ToLower(data.LocalPart.ValueString()) + "@" + data.DomainName.ValueASCII())
+	data.Password = types.StringValue(password)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// generatePassword returns a random password of the given length that
+// contains at least one lowercase letter, uppercase letter and digit, and at
+// least one special character when special is true.
+func generatePassword(length int, special bool) (string, error) {
+	classes := []string{passwordLowerChars, passwordUpperChars, passwordDigitChars}
+	if special {
+		classes = append(classes, passwordSpecialChars)
+	}
+
+	if length < len(classes) {
+		return "", fmt.Errorf("password length %d is too short to include every character class", length)
+	}
+
+	charset := strings.Join(classes, "")
+
+	password := make([]byte, length)
+	for i := range password {
+		// Fill the first positions with one character from each class so
+		// the policy always holds, and the rest from the full charset.
+		chars := charset
+		if i < len(classes) {
+			chars = classes[i]
+		}
+
+		c, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Shuffle so the guaranteed characters do not sit at fixed positions.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[n.Int64()], nil
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccMailboxPasswordEphemeralResource_basic(t *testing.T) {
+	server := testFakeSetup(t)
+	localPart := "tfacc-mailbox-password"
+
+	_, err := testAccClient(t).NewMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{
+		LocalPart:      localPart,
+		PasswordMethod: "password",
+		Password:       "initial-secret",
+	})
+	if err != nil {
+		t.Fatalf("failed creating mailbox: %s", err)
+	}
+
+	// Opening the ephemeral resource during plan and apply leaves the mailbox
+	// password alone.
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+ephemeral "migadu_mailbox_password" "test" {
+  domain_name = "%s"
+  local_part  = "%s"
+}
+`, testFakeDomain, localPart),
+				Check: func(*terraform.State) error {
+					password, ok := server.MailboxPassword(testFakeDomain, localPart)
+					if !ok {
+						return fmt.Errorf("mailbox %s not found", localPart)
+					}
+					if password != "initial-secret" {
+						return fmt.Errorf("expected the mailbox password to be unchanged, got %q", password)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccMailboxPasswordEphemeralResource_passwordWO(t *testing.T) {
+	server := testFakeSetup(t)
+	localPart := "tfacc-mailbox-password"
+
+	var lastPassword string
+	checkPassword := func(changed bool) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			password, ok := server.MailboxPassword(testFakeDomain, localPart)
+			if !ok {
+				return fmt.Errorf("mailbox %s not found", localPart)
+			}
+			if changed == (password == lastPassword) {
+				return fmt.Errorf("expected the mailbox password to be changed: %t, got %q after %q", changed, password, lastPassword)
+			}
+			assertPasswordPolicy(t, password, defaultGeneratedPasswordLength, true)
+			lastPassword = password
+			return nil
+		}
+	}
+
+	config := func(version int) string {
+		return fmt.Sprintf(`
+ephemeral "migadu_mailbox_password" "test" {
+  domain_name = "%s"
+  local_part  = "%s"
+}
+
+resource "migadu_mailbox" "test" {
+  domain_name         = "%s"
+  local_part          = "%s"
+  name                = "Terraform Acceptance"
+  password_method     = "password"
+  password_wo         = ephemeral.migadu_mailbox_password.test.password
+  password_wo_version = %d
+}
+`, testFakeDomain, localPart, testFakeDomain, localPart, version)
+	}
+
+	// The generated password reaches the mailbox through password_wo, so it
+	// only changes when the version does.
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(1),
+				Check:  checkPassword(true),
+			},
+			{
+				Config: config(1),
+				Check:  checkPassword(false),
+			},
+			{
+				Config: config(2),
+				Check:  checkPassword(true),
+			},
+		},
+	})
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	testCases := map[string]struct {
+		length  int
+		special bool
+	}{
+		"default":    {length: defaultGeneratedPasswordLength, special: true},
+		"minimum":    {length: minGeneratedPasswordLength, special: true},
+		"maximum":    {length: maxGeneratedPasswordLength, special: true},
+		"no special": {length: minGeneratedPasswordLength, special: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				password, err := generatePassword(tc.length, tc.special)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				assertPasswordPolicy(t, password, tc.length, tc.special)
+			}
+		})
+	}
+}
+
+func TestGeneratePasswordIsRandom(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		password, err := generatePassword(minGeneratedPasswordLength, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if seen[password] {
+			t.Fatalf("generated password %q twice", password)
+		}
+		seen[password] = true
+	}
+}
+
+func TestGeneratePasswordTooShort(t *testing.T) {
+	if _, err := generatePassword(3, true); err == nil {
+		t.Fatal("expected error for a length shorter than the number of character classes")
+	}
+}
+
+func assertPasswordPolicy(t *testing.T, password string, length int, special bool) {
+	t.Helper()
+
+	if len(password) != length {
+		t.Fatalf("expected password of length %d, got %d", length, len(password))
+	}
+
+	allowed := passwordLowerChars + passwordUpperChars + passwordDigitChars
+	if special {
+		allowed += passwordSpecialChars
+	}
+	for _, c := range password {
+		if !strings.ContainsRune(allowed, c) {
+			t.Fatalf("password contains unexpected character %q", c)
+		}
+	}
+
+	classes := map[string]string{
+		"lowercase": passwordLowerChars,
+		"uppercase": passwordUpperChars,
+		"digit":     passwordDigitChars,
+	}
+	if special {
+		classes["special"] = passwordSpecialChars
+	}
+	for class, chars := range classes {
+		if !strings.ContainsAny(password, chars) {
+			t.Fatalf("password %q is missing a %s character", password, class)
+		}
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"strings"
 
 	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -25,6 +28,10 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MailboxResource{}
 var _ resource.ResourceWithImportState = &MailboxResource{}
+var _ resource.ResourceWithIdentity = &MailboxResource{}
+var _ resource.ResourceWithMoveState = &MailboxResource{}
+var _ resource.ResourceWithConfigValidators = &MailboxResource{}
+var _ resource.ResourceWithValidateConfig = &MailboxResource{}
 
 func NewMailboxResource() resource.Resource {
 	return &MailboxResource{}
@@ -37,30 +44,43 @@ type MailboxResource struct {
 
 // MailboxResourceModel describes the resource data model.
 type MailboxResourceModel struct {
-	DomainName            types.String `tfsdk:"domain_name"`
-	LocalPart             types.String `tfsdk:"local_part"`
-	Name                  types.String `tfsdk:"name"`
-	PasswordMethod        types.String `tfsdk:"password_method"`
-	Password              types.String `tfsdk:"password"`
-	PasswordRecoveryEmail types.String `tfsdk:"password_recovery_email"`
-	MaySend               types.Bool   `tfsdk:"may_send"`
-	MayReceive            types.Bool   `tfsdk:"may_receive"`
-	MayAccessImap         types.Bool   `tfsdk:"may_access_imap"`
-	MayAccessPop3         types.Bool   `tfsdk:"may_access_pop3"`
-	MayAccessManageSieve  types.Bool   `tfsdk:"may_access_managesieve"`
-	SpamAction            types.String `tfsdk:"spam_action"`
-	SpamAggressiveness    types.String `tfsdk:"spam_aggressiveness"`
-	FooterActive          types.Bool   `tfsdk:"footer_active"`
-	FooterPlainBody       types.String `tfsdk:"footer_plain_body"`
-	FooterHTMLBody        types.String `tfsdk:"footer_html_body"`
-	SenderAllowlist       types.List   `tfsdk:"sender_allowlist"`
-	SenderDenylist        types.List   `tfsdk:"sender_denylist"`
-	RecipientDenylist     types.List   `tfsdk:"recipient_denylist"`
-	Address               types.String `tfsdk:"address"`
-	IsInternal            types.Bool   `tfsdk:"is_internal"`
-	StorageUsage          types.Int64  `tfsdk:"storage_usage"`
-	ChangedAt             types.String `tfsdk:"changed_at"`
-	LastLoginAt           types.String `tfsdk:"last_login_at"`
+	DomainName            DomainNameValue    `tfsdk:"domain_name"`
+	LocalPart             LocalPartValue     `tfsdk:"local_part"`
+	Name                  types.String       `tfsdk:"name"`
+	PasswordMethod        types.String       `tfsdk:"password_method"`
+	Password              types.String       `tfsdk:"password"`
+	PasswordWO            types.String       `tfsdk:"password_wo"`
+	PasswordWOVersion     types.Int64        `tfsdk:"password_wo_version"`
+	PasswordRecoveryEmail EmailAddressValue  `tfsdk:"password_recovery_email"`
+	MaySend               types.Bool         `tfsdk:"may_send"`
+	MayReceive            types.Bool         `tfsdk:"may_receive"`
+	MayAccessImap         types.Bool         `tfsdk:"may_access_imap"`
+	MayAccessPop3         types.Bool         `tfsdk:"may_access_pop3"`
+	MayAccessManageSieve  types.Bool         `tfsdk:"may_access_managesieve"`
+	SpamAction            types.String       `tfsdk:"spam_action"`
+	SpamAggressiveness    types.String       `tfsdk:"spam_aggressiveness"`
+	FooterActive          types.Bool         `tfsdk:"footer_active"`
+	FooterPlainBody       types.String       `tfsdk:"footer_plain_body"`
+	FooterHTMLBody        types.String       `tfsdk:"footer_html_body"`
+	SenderAllowlist       UnorderedListValue `tfsdk:"sender_allowlist"`
+	SenderDenylist        UnorderedListValue `tfsdk:"sender_denylist"`
+	RecipientDenylist     UnorderedListValue `tfsdk:"recipient_denylist"`
+	Address               EmailAddressValue  `tfsdk:"address"`
+	IsInternal            types.Bool         `tfsdk:"is_internal"`
+	Expirable             types.Bool         `tfsdk:"expirable"`
+	ExpiresOn             types.String       `tfsdk:"expires_on"`
+	RemoveUponExpiry      types.Bool         `tfsdk:"remove_upon_expiry"`
+	StorageUsage          types.Int64        `tfsdk:"storage_usage"`
+	ChangedAt             types.String       `tfsdk:"changed_at"`
+	LastLoginAt           types.String       `tfsdk:"last_login_at"`
+	AdoptExisting         types.Bool         `tfsdk:"adopt_existing"`
+	Timeouts              timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// MailboxResourceIdentityModel describes the resource identity data model.
+type MailboxResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	LocalPart  types.String `tfsdk:"local_part"`
 }
 
 func (r *MailboxResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -73,16 +93,22 @@ func (r *MailboxResource) Schema(ctx context.Context, req resource.SchemaRequest
 
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
-				MarkdownDescription: "The domain name for this mailbox.",
-				Required:            true,
+				MarkdownDescription: "The domain name for this mailbox. Required unless `address` is set.",
+				CustomType:          DomainNameType{},
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					addressPart("domain_name"),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"local_part": schema.StringAttribute{
-				MarkdownDescription: "The local part of the email address (before the @).",
-				Required:            true,
+				MarkdownDescription: "The local part of the email address (before the @). Required unless `address` is set.",
+				CustomType:          LocalPartType{},
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					addressPart("local_part"),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -101,14 +127,39 @@ func (r *MailboxResource) Schema(ctx context.Context, req resource.SchemaRequest
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("password", "invitation"),
+				},
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "The password for the mailbox. Required if password_method is 'password'.",
+				MarkdownDescription: "The password for the mailbox. Required if password_method is 'password', unless `password_wo` is used instead. Stored in state; prefer `password_wo`.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password_wo")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only password for the mailbox, never stored in state. Requires Terraform 1.11 or later. " +
+					"Sent on create and whenever `password_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+					stringvalidator.AlsoRequires(path.MatchRoot("password_wo_version")),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `password_wo`. Change this value to send a new `password_wo` to Migadu.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
 			},
 			"password_recovery_email": schema.StringAttribute{
 				MarkdownDescription: "Recovery email address for password resets. Required when `password_method` is `invitation`.",
+				CustomType:          EmailAddressType{},
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString(""),
@@ -181,35 +232,41 @@ func (r *MailboxResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Default:             stringdefault.StaticString(""),
 			},
 			"sender_allowlist": schema.ListAttribute{
-				MarkdownDescription: "List of allowed sender addresses.",
+				MarkdownDescription: "List of allowed sender addresses or domains.",
 				Optional:            true,
 				Computed:            true,
-				ElementType:         types.StringType,
-				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				ElementType:         AddressOrDomainType{},
+				CustomType:          NewUnorderedListType(AddressOrDomainType{}),
+				Default:             listdefault.StaticValue(types.ListValueMust(AddressOrDomainType{}, []attr.Value{})),
 			},
 			"sender_denylist": schema.ListAttribute{
-				MarkdownDescription: "List of denied sender addresses.",
+				MarkdownDescription: "List of denied sender addresses or domains.",
 				Optional:            true,
 				Computed:            true,
-				ElementType:         types.StringType,
-				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				ElementType:         AddressOrDomainType{},
+				CustomType:          NewUnorderedListType(AddressOrDomainType{}),
+				Default:             listdefault.StaticValue(types.ListValueMust(AddressOrDomainType{}, []attr.Value{})),
 			},
 			"recipient_denylist": schema.ListAttribute{
-				MarkdownDescription: "List of denied recipient addresses.",
+				MarkdownDescription: "List of denied recipient addresses or domains.",
 				Optional:            true,
 				Computed:            true,
-				ElementType:         types.StringType,
-				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				ElementType:         AddressOrDomainType{},
+				CustomType:          NewUnorderedListType(AddressOrDomainType{}),
+				Default:             listdefault.StaticValue(types.ListValueMust(AddressOrDomainType{}, []attr.Value{})),
 			},
 			"address": schema.StringAttribute{
-				MarkdownDescription: "Full email address (computed).",
+				MarkdownDescription: "Full email address of the mailbox, known during plan. Can be set instead of `domain_name` and `local_part`, which are then derived from it in lowercase.",
+				Optional:            true,
 				Computed:            true,
+				CustomType:          EmailAddressType{},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					addressFromParts(),
 				},
 			},
 			"is_internal": schema.BoolAttribute{
-				MarkdownDescription: "Whether this is an internal mailbox (computed).",
+				MarkdownDescription: "Whether the mailbox only accepts mail from senders on the same domain. When unset, the value in Migadu is kept.",
+				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.UseStateForUnknown(),
@@ -236,10 +293,127 @@ func (r *MailboxResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"expirable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the mailbox expires on `expires_on`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "Date in `YYYY-MM-DD` format on which the mailbox expires. Required when `expirable` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					isDate(),
+				},
+			},
+			"remove_upon_expiry": schema.BoolAttribute{
+				MarkdownDescription: "Whether Migadu deletes the mailbox and its messages once it expires. " +
+					"The resource is then removed from state on the next refresh. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over an existing mailbox when creating this resource finds one already there, instead of failing. " +
+					"The existing mailbox is updated to match this configuration. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *MailboxResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the mailbox.",
+				RequiredForImport: true,
+			},
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the mailbox address (before the @).",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
 
+func (r *MailboxResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromMetio("migadu_mailbox", metioMailboxSchema(), []string{"domain_name", "local_part"}, moveMailboxFromMetio),
+	}
+}
+
+func (r *MailboxResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return append(addressConfigValidators(), expiryConfigValidator{})
+}
+
+// ValidateConfig checks that the password attributes match password_method,
+// so that mistakes show up at plan time rather than as an API error. Checks
+// are skipped while password_method is unset or unknown, since an omitted
+// method leaves the existing one unchanged on update.
+func (r *MailboxResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var passwordMethod, password, passwordWO types.String
+	var passwordRecoveryEmail EmailAddressValue
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_method"), &passwordMethod)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_recovery_email"), &passwordRecoveryEmail)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if passwordMethod.IsNull() || passwordMethod.IsUnknown() {
+		return
+	}
+
+	switch passwordMethod.ValueString() {
+	case "password":
+		if password.IsNull() && passwordWO.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing Password",
+				"When password_method is 'password', either password or password_wo must be provided.",
+			)
+		}
+	case "invitation":
+		if !password.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Conflicting Password",
+				"When password_method is 'invitation', password must not be set. The mailbox owner chooses a password from the invitation.",
+			)
+		}
+		if !passwordWO.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password_wo"),
+				"Conflicting Password",
+				"When password_method is 'invitation', password_wo must not be set. The mailbox owner chooses a password from the invitation.",
+			)
+		}
+		if passwordRecoveryEmail.IsNull() || (!passwordRecoveryEmail.IsUnknown() && passwordRecoveryEmail.ValueString() == "") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password_recovery_email"),
+				"Missing Password Recovery Email",
+				"When password_method is 'invitation', password_recovery_email must be provided. Migadu sends the invitation to this address.",
+			)
+		}
+	}
+}
+
 func (r *MailboxResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -270,11 +444,28 @@ func (r *MailboxResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Write-only values are only available from the configuration.
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	passwordMethodIsSet := !data.PasswordMethod.IsNull() && !data.PasswordMethod.IsUnknown()
 	passwordMethod := "invitation"
-	if !data.PasswordMethod.IsNull() && !data.PasswordMethod.IsUnknown() {
+	if passwordMethodIsSet {
 		passwordMethod = data.PasswordMethod.ValueString()
 	}
-	if passwordMethod == "password" && data.Password.IsNull() {
+	if passwordMethod == "password" && data.Password.IsNull() && passwordWO.IsNull() {
 		resp.Diagnostics.AddError(
 			"Missing Password",
 			"When password_method is 'password', the password field must be provided.",
@@ -295,7 +486,7 @@ func (r *MailboxResource) Create(ctx context.Context, req resource.CreateRequest
 		LocalPart:             data.LocalPart.ValueString(),
 		Name:                  data.Name.ValueString(),
 		PasswordMethod:        passwordMethod,
-		PasswordRecoveryEmail: data.PasswordRecoveryEmail.ValueString(),
+		PasswordRecoveryEmail: data.PasswordRecoveryEmail.ValueASCII(),
 		MaySend:               data.MaySend.ValueBool(),
 		MayReceive:            data.MayReceive.ValueBool(),
 		MayAccessImap:         data.MayAccessImap.ValueBool(),
@@ -306,32 +497,74 @@ func (r *MailboxResource) Create(ctx context.Context, req resource.CreateRequest
 		FooterActive:          data.FooterActive.ValueBool(),
 		FooterPlainBody:       data.FooterPlainBody.ValueString(),
 		FooterHTMLBody:        data.FooterHTMLBody.ValueString(),
-		SenderAllowlist:       senderAllowlist,
-		SenderDenylist:        senderDenylist,
-		RecipientDenylist:     recipientDenylist,
+		SenderAllowlist:       addressesToASCII(senderAllowlist),
+		SenderDenylist:        addressesToASCII(senderDenylist),
+		RecipientDenylist:     addressesToASCII(recipientDenylist),
+		IsInternal:            data.IsInternal.ValueBool(),
+		Expireable:            data.Expirable.ValueBool(),
+		ExpiresOn:             data.ExpiresOn.ValueString(),
+		RemoveUponExpiry:      data.RemoveUponExpiry.ValueBool(),
 	}
 	if !data.Password.IsNull() && !data.Password.IsUnknown() {
 		mailbox.Password = data.Password.ValueString()
 	}
+	if !passwordWO.IsNull() {
+		mailbox.Password = passwordWO.ValueString()
+	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	// An adopted mailbox is updated like on Update, so its login is only
+	// changed when the configuration sets a password method.
+	adopted := *mailbox
+	if !passwordMethodIsSet {
+		adopted.PasswordMethod = ""
+	}
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 
-	// Create the mailbox
-	created, err := r.client.NewMailbox(ctx, domain, mailbox)
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
+	// Create the mailbox, or adopt an existing one
+	var existing *migadu.Mailbox
+	created, err := createOrAdopt(ctx, data.AdoptExisting.ValueBool(), mailbox.LocalPart+"@"+domain.Name,
+		func() (*migadu.Mailbox, error) { return r.client.NewMailbox(ctx, domain, mailbox) },
+		func() (*migadu.Mailbox, error) {
+			got, err := r.client.GetMailbox(ctx, domain, mailbox)
+			existing = got
+			return got, err
+		},
+		func() (*migadu.Mailbox, error) {
+			// The autoresponder is managed by migadu_mailbox_autoresponder,
+			// so send back what Migadu has, as on Update. is_internal is
+			// also kept unless it is configured.
+			copyAutoresponder(&adopted, existing)
+			if data.IsInternal.IsUnknown() {
+				adopted.IsInternal = existing.IsInternal
+			}
+			return r.client.UpdateMailbox(ctx, domain, &adopted)
+		},
+	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create mailbox, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "create mailbox", err)
 		return
 	}
 
 	// Update the state with the created mailbox data
+	if !passwordMethodIsSet && created.PasswordMethod != "" {
+		passwordMethod = created.PasswordMethod
+	}
 	data.PasswordMethod = types.StringValue(passwordMethod)
-	data.Address = types.StringValue(created.Address)
+	data.Address = appliedAddress(&resp.Diagnostics, data.Address, created.Address)
 	data.IsInternal = types.BoolValue(created.IsInternal)
 	data.StorageUsage = types.Int64Value(int64(created.StorageUsage))
 	data.ChangedAt = types.StringValue(created.ChangedAt)
 	data.LastLoginAt = types.StringValue(created.LastLoginAt)
 
 	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MailboxResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -345,19 +578,38 @@ func (r *MailboxResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MailboxResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	localPart := data.LocalPart.ValueString()
 
 	// Get current state from API
 	mailbox, err := r.client.GetMailbox(ctx, domain, &migadu.Mailbox{LocalPart: localPart})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read mailbox, got error: %s", err))
+		// The mailbox is gone, for example removed by Migadu upon expiry.
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read mailbox", err)
 		return
 	}
 
 	// Update state with API data
 	data.Name = types.StringValue(mailbox.Name)
-	data.PasswordRecoveryEmail = types.StringValue(mailbox.PasswordRecoveryEmail)
+	data.PasswordRecoveryEmail = NewEmailAddressValue(mailbox.PasswordRecoveryEmail)
 	data.MaySend = types.BoolValue(mailbox.MaySend)
 	data.MayReceive = types.BoolValue(mailbox.MayReceive)
 	data.MayAccessImap = types.BoolValue(mailbox.MayAccessImap)
@@ -369,24 +621,32 @@ func (r *MailboxResource) Read(ctx context.Context, req resource.ReadRequest, re
 	data.FooterPlainBody = types.StringValue(mailbox.FooterPlainBody)
 	data.FooterHTMLBody = types.StringValue(mailbox.FooterHTMLBody)
 
-	senderAllowlist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.SenderAllowlist))
+	senderAllowlist, diags := NewUnorderedListValueFrom(ctx, AddressOrDomainType{}, mailbox.SenderAllowlist)
 	resp.Diagnostics.Append(diags...)
 	data.SenderAllowlist = senderAllowlist
 
-	senderDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.SenderDenylist))
+	senderDenylist, diags := NewUnorderedListValueFrom(ctx, AddressOrDomainType{}, mailbox.SenderDenylist)
 	resp.Diagnostics.Append(diags...)
 	data.SenderDenylist = senderDenylist
 
-	recipientDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.RecipientDenylist))
+	recipientDenylist, diags := NewUnorderedListValueFrom(ctx, AddressOrDomainType{}, mailbox.RecipientDenylist)
 	resp.Diagnostics.Append(diags...)
 	data.RecipientDenylist = recipientDenylist
 
-	data.Address = types.StringValue(mailbox.Address)
+	data.Address = stateAddress(data.Address, mailbox.Address)
 	data.IsInternal = types.BoolValue(mailbox.IsInternal)
+	data.Expirable = types.BoolValue(mailbox.Expireable)
+	data.ExpiresOn = types.StringValue(mailbox.ExpiresOn)
+	data.RemoveUponExpiry = types.BoolValue(mailbox.RemoveUponExpiry)
 	data.StorageUsage = types.Int64Value(int64(mailbox.StorageUsage))
 	data.ChangedAt = types.StringValue(mailbox.ChangedAt)
 	data.LastLoginAt = types.StringValue(mailbox.LastLoginAt)
 
+	// adopt_existing only affects create; imported resources take the default.
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -401,8 +661,25 @@ func (r *MailboxResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var passwordWO types.String
+	var priorPasswordWOVersion types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password_wo_version"), &priorPasswordWOVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	passwordMethodIsSet := !data.PasswordMethod.IsNull() && !data.PasswordMethod.IsUnknown()
-	if passwordMethodIsSet && data.PasswordMethod.ValueString() == "password" && data.Password.IsNull() {
+	if passwordMethodIsSet && data.PasswordMethod.ValueString() == "password" && data.Password.IsNull() && passwordWO.IsNull() {
 		resp.Diagnostics.AddError(
 			"Missing Password",
 			"When password_method is 'password', the password field must be provided.",
@@ -422,7 +699,7 @@ func (r *MailboxResource) Update(ctx context.Context, req resource.UpdateRequest
 	mailbox := &migadu.Mailbox{
 		LocalPart:             data.LocalPart.ValueString(),
 		Name:                  data.Name.ValueString(),
-		PasswordRecoveryEmail: data.PasswordRecoveryEmail.ValueString(),
+		PasswordRecoveryEmail: data.PasswordRecoveryEmail.ValueASCII(),
 		MaySend:               data.MaySend.ValueBool(),
 		MayReceive:            data.MayReceive.ValueBool(),
 		MayAccessImap:         data.MayAccessImap.ValueBool(),
@@ -433,9 +710,13 @@ func (r *MailboxResource) Update(ctx context.Context, req resource.UpdateRequest
 		FooterActive:          data.FooterActive.ValueBool(),
 		FooterPlainBody:       data.FooterPlainBody.ValueString(),
 		FooterHTMLBody:        data.FooterHTMLBody.ValueString(),
-		SenderAllowlist:       senderAllowlist,
-		SenderDenylist:        senderDenylist,
-		RecipientDenylist:     recipientDenylist,
+		SenderAllowlist:       addressesToASCII(senderAllowlist),
+		SenderDenylist:        addressesToASCII(senderDenylist),
+		RecipientDenylist:     addressesToASCII(recipientDenylist),
+		IsInternal:            data.IsInternal.ValueBool(),
+		Expireable:            data.Expirable.ValueBool(),
+		ExpiresOn:             data.ExpiresOn.ValueString(),
+		RemoveUponExpiry:      data.RemoveUponExpiry.ValueBool(),
 	}
 	if passwordMethodIsSet {
 		mailbox.PasswordMethod = data.PasswordMethod.ValueString()
@@ -443,23 +724,43 @@ func (r *MailboxResource) Update(ctx context.Context, req resource.UpdateRequest
 	if !data.Password.IsNull() && !data.Password.IsUnknown() {
 		mailbox.Password = data.Password.ValueString()
 	}
+	// The write-only password is only resent when its version changes.
+	if !passwordWO.IsNull() && !data.PasswordWOVersion.Equal(priorPasswordWOVersion) {
+		mailbox.Password = passwordWO.ValueString()
+	}
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	// The autoresponder is managed by migadu_mailbox_autoresponder, but the
+	// API only updates whole mailboxes, so send back what Migadu has.
+	current, err := r.client.GetMailbox(ctx, domain, mailbox)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "read mailbox", err)
+		return
+	}
+	copyAutoresponder(mailbox, current)
 
 	// Update the mailbox
 	updated, err := r.client.UpdateMailbox(ctx, domain, mailbox)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update mailbox, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "update mailbox", err)
 		return
 	}
 
 	// Update the state with the updated mailbox data
 	// Note: changed_at and last_login_at are intentionally not updated as they cause
 	// provider consistency errors. These computed values will refresh on the next read.
-	data.Address = types.StringValue(updated.Address)
+	data.Address = appliedAddress(&resp.Diagnostics, data.Address, updated.Address)
 	data.IsInternal = types.BoolValue(updated.IsInternal)
 	data.StorageUsage = types.Int64Value(int64(updated.StorageUsage))
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MailboxResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		LocalPart:  data.LocalPart.StringValue,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -473,31 +774,39 @@ func (r *MailboxResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	mailbox := &migadu.Mailbox{
 		LocalPart: data.LocalPart.ValueString(),
 	}
 
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
 	// Delete the mailbox
 	err := r.client.DeleteMailbox(ctx, domain, mailbox)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete mailbox, got error: %s", err))
+	// The mailbox may already be gone, such as when Migadu removed it upon
+	// expiry.
+	if err != nil && !isNotFoundError(err) {
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "delete mailbox", err)
 		return
 	}
 }
 
-
 func (r *MailboxResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Format: domain_name/local_part
-	parts := strings.Split(req.ID, "/")
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Import ID must be in format: domain_name/local_part",
-		)
+	// Format: domain_name/local_part or the email address
+	if strings.Contains(req.ID, "@") {
+		importStateByAddress(ctx, req.ID, resp)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("local_part"), parts[1])...)
+	importStateByKey(ctx, req, resp, "domain_name", "local_part")
 }
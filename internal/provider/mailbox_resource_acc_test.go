@@ -1,39 +1,566 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/MrLemur/migadu-go"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccMailboxResource_basic(t *testing.T) {
-	if os.Getenv("TF_ACC") == "" {
-		t.Skip("set TF_ACC=1 to run acceptance tests")
-	}
+	domainName := testAccSetup(t)
 
 	resourceName := "migadu_mailbox.test"
 	localPart := fmt.Sprintf("tfacc-mailbox-%d", time.Now().UnixNano())
-	importID := fmt.Sprintf("%s/%s", os.Getenv("MIGADU_TEST_DOMAIN"), localPart)
+	importID := fmt.Sprintf("%s/%s", domainName, localPart)
 
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccMailboxConfig(localPart),
+				Config: testAccMailboxConfig(domainName, localPart),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "domain_name", os.Getenv("MIGADU_TEST_DOMAIN")),
+					resource.TestCheckResourceAttr(resourceName, "domain_name", domainName),
 					resource.TestCheckResourceAttr(resourceName, "local_part", localPart),
+					resource.TestCheckResourceAttr(resourceName, "address", fmt.Sprintf("%s@%s", localPart, domainName)),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        importID,
+				ImportStateVerifyIdentifierAttribute: "address",
+				// password_method is not returned by the API
+				ImportStateVerifyIgnore: []string{"password_method"},
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_disappears(t *testing.T) {
+	domainName := testAccSetup(t)
+	localPart := fmt.Sprintf("tfacc-mailbox-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailboxConfig(domainName, localPart),
+			},
+			{
+				PreConfig: func() {
+					err := testAccClient(t).DeleteMailbox(context.Background(), &migadu.Domain{Name: domainName}, &migadu.Mailbox{LocalPart: localPart})
+					if err != nil {
+						t.Fatalf("failed deleting mailbox out of band: %s", err)
+					}
+				},
+				Config: testAccMailboxConfig(domainName, localPart),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("migadu_mailbox.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_passwordWO(t *testing.T) {
+	server := testFakeSetup(t)
+	resourceName := "migadu_mailbox.test"
+	localPart := "tfacc-mailbox-wo"
+
+	checkPassword := func(expected string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			password, ok := server.MailboxPassword(testFakeDomain, localPart)
+			if !ok {
+				return fmt.Errorf("mailbox %s not found", localPart)
+			}
+			if password != expected {
+				return fmt.Errorf("expected Migadu to hold password %q, got %q", expected, password)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailboxPasswordWOConfig(localPart, "first-secret", 1),
+				Check: resource.ComposeTestCheckFunc(
+					checkPassword("first-secret"),
+					resource.TestCheckNoResourceAttr(resourceName, "password_wo"),
+					resource.TestCheckNoResourceAttr(resourceName, "password"),
+					resource.TestCheckResourceAttr(resourceName, "password_wo_version", "1"),
+				),
+			},
+			{
+				// A new value without a version bump is not sent.
+				Config: testAccMailboxPasswordWOConfig(localPart, "second-secret", 1),
+				Check:  checkPassword("first-secret"),
+			},
+			{
+				Config: testAccMailboxPasswordWOConfig(localPart, "second-secret", 2),
+				Check: resource.ComposeTestCheckFunc(
+					checkPassword("second-secret"),
+					resource.TestCheckNoResourceAttr(resourceName, "password_wo"),
+					resource.TestCheckResourceAttr(resourceName, "password_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMailboxPasswordWOConfig(localPart, password string, version int) string {
+	return fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name         = "%s"
+  local_part          = "%s"
+  name                = "Terraform Acceptance %s"
+  password_method     = "password"
+  password_wo         = "%s"
+  password_wo_version = %d
+}
+`, testFakeDomain, localPart, localPart, password, version)
+}
+
+func TestAccMailboxResource_alreadyExists(t *testing.T) {
+	testFakeSetup(t)
+	localPart := "tfacc-mailbox-exists"
+
+	_, err := testAccClient(t).NewMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{
+		LocalPart:      localPart,
+		PasswordMethod: "password",
+		Password:       "created-by-hand",
+	})
+	if err != nil {
+		t.Fatalf("failed creating mailbox out of band: %s", err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMailboxConfig(testFakeDomain, localPart),
+				ExpectError: regexp.MustCompile(`Migadu Object Already Exists`),
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_adoptExisting(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_mailbox.test"
+	localPart := "tfacc-mailbox-adopt"
+
+	_, err := testAccClient(t).NewMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{
+		LocalPart:      localPart,
+		Name:           "Created By Hand",
+		PasswordMethod: "password",
+		Password:       "created-by-hand",
+		IsInternal:     true,
+	})
+	if err != nil {
+		t.Fatalf("failed creating mailbox out of band: %s", err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name             = "%s"
+  local_part              = "%s"
+  name                    = "Adopted"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
+  adopt_existing          = true
+}
+`, testFakeDomain, localPart),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Adopted"),
+					resource.TestCheckResourceAttr(resourceName, "adopt_existing", "true"),
+					resource.TestCheckResourceAttr(resourceName, "address", localPart+"@"+testFakeDomain),
+					// is_internal is not configured, so the adopted mailbox keeps it.
+					resource.TestCheckResourceAttr(resourceName, "is_internal", "true"),
+					func(*terraform.State) error {
+						mailbox, err := testAccClient(t).GetMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{LocalPart: localPart})
+						if err != nil {
+							return err
+						}
+						if mailbox.Name != "Adopted" {
+							return fmt.Errorf("expected the existing mailbox to be updated, got name %q", mailbox.Name)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_adoptExistingKeepsLogin(t *testing.T) {
+	server := testFakeSetup(t)
+	resourceName := "migadu_mailbox.test"
+	localPart := "tfacc-mailbox-adopt-login"
+
+	_, err := testAccClient(t).NewMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{
+		LocalPart:      localPart,
+		Name:           "Created By Hand",
+		PasswordMethod: "password",
+		Password:       "created-by-hand",
+	})
+	if err != nil {
+		t.Fatalf("failed creating mailbox out of band: %s", err)
+	}
+
+	config := fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name             = "%s"
+  local_part              = "%s"
+  name                    = "Adopted"
+  password_recovery_email = "recovery@example.net"
+  adopt_existing          = true
+}
+`, testFakeDomain, localPart)
+
+	// Without password_method in the configuration, adopting the mailbox
+	// leaves its password method and password as they are.
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Adopted"),
+					resource.TestCheckResourceAttr(resourceName, "password_method", "password"),
+					func(*terraform.State) error {
+						mailbox, err := testAccClient(t).GetMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{LocalPart: localPart})
+						if err != nil {
+							return err
+						}
+						if mailbox.PasswordMethod != "password" {
+							return fmt.Errorf("expected the password method to be unchanged, got %q", mailbox.PasswordMethod)
+						}
+						if password, _ := server.MailboxPassword(testFakeDomain, localPart); password != "created-by-hand" {
+							return fmt.Errorf("expected the password to be unchanged, got %q", password)
+						}
+						return nil
+					},
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateId:     importID,
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_adoptExistingKeepsAutoresponder(t *testing.T) {
+	testFakeSetup(t)
+	localPart := "tfacc-mailbox-adopt-autoresponder"
+
+	_, err := testAccClient(t).NewMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{
+		LocalPart:          localPart,
+		Name:               "Created By Hand",
+		PasswordMethod:     "password",
+		Password:           "created-by-hand",
+		AutorespondActive:  true,
+		AutorespondSubject: "Out of office",
+		AutorespondBody:    "Back next week.",
+	})
+	if err != nil {
+		t.Fatalf("failed creating mailbox out of band: %s", err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name             = "%s"
+  local_part              = "%s"
+  name                    = "Adopted"
+  password_recovery_email = "recovery@example.net"
+  adopt_existing          = true
+}
+`, testFakeDomain, localPart),
+				Check: func(*terraform.State) error {
+					mailbox, err := testAccClient(t).GetMailbox(context.Background(), &migadu.Domain{Name: testFakeDomain}, &migadu.Mailbox{LocalPart: localPart})
+					if err != nil {
+						return err
+					}
+					if !mailbox.AutorespondActive || mailbox.AutorespondSubject != "Out of office" || mailbox.AutorespondBody != "Back next week." {
+						return fmt.Errorf("expected the autoresponder to be unchanged, got active %t, subject %q, body %q",
+							mailbox.AutorespondActive, mailbox.AutorespondSubject, mailbox.AutorespondBody)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_address(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_mailbox.test"
+
+	config := func(addressing string) string {
+		return fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  %s
+  name                    = "Addressed"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
+}
+`, addressing)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf(`
+  address     = "tfacc-address@%[1]s"
+  domain_name = "%[1]s"
+`, testFakeDomain)),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config:      config(`address = "tfacc-address"`),
+				ExpectError: regexp.MustCompile(`is not a valid email address`),
+			},
+			{
+				Config: config(fmt.Sprintf(`address = "TfAcc-Address@%s"`, strings.ToUpper(testFakeDomain))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "address", "TfAcc-Address@"+strings.ToUpper(testFakeDomain)),
+					resource.TestCheckResourceAttr(resourceName, "domain_name", testFakeDomain),
+					resource.TestCheckResourceAttr(resourceName, "local_part", "tfacc-address"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        "tfacc-address@" + testFakeDomain,
+				ImportStateVerifyIdentifierAttribute: "local_part",
+				// The configured address keeps its case; password_method is not
+				// returned by the API.
+				ImportStateVerifyIgnore: []string{"address", "password_method"},
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_addressKnownAtPlan(t *testing.T) {
+	testFakeSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name             = "%s"
+  local_part              = "TfAcc-Planned"
+  password_method         = "invitation"
+  password_recovery_email = "recovery@example.net"
+}
+
+resource "migadu_alias" "test" {
+  domain_name  = migadu_mailbox.test.domain_name
+  local_part   = "tfacc-planned-alias"
+  destinations = [migadu_mailbox.test.address]
+}
+`, testFakeDomain),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("migadu_mailbox.test", tfjsonpath.New("address"), knownvalue.StringExact("tfacc-planned@"+testFakeDomain)),
+						plancheck.ExpectKnownValue("migadu_alias.test", tfjsonpath.New("address"), knownvalue.StringExact("tfacc-planned-alias@"+testFakeDomain)),
+						plancheck.ExpectKnownValue("migadu_alias.test", tfjsonpath.New("destinations"), knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("tfacc-planned@" + testFakeDomain),
+						})),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("migadu_mailbox.test", "address", "tfacc-planned@"+testFakeDomain),
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_expiry(t *testing.T) {
+	server := testFakeSetup(t)
+	resourceName := "migadu_mailbox.test"
+
+	config := func(removeUponExpiry bool) string {
+		return fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name        = "%s"
+  local_part         = "tfacc-mailbox-expiry"
+  name               = "Temporary"
+  password_method    = "password"
+  password           = "correct-horse"
+  expirable          = true
+  expires_on         = "2030-01-31"
+  remove_upon_expiry = %t
+}
+`, testFakeDomain, removeUponExpiry)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name     = "%s"
+  local_part      = "tfacc-mailbox-expiry"
+  name            = "Temporary"
+  password_method = "password"
+  password        = "correct-horse"
+  expirable       = true
+}
+`, testFakeDomain),
+				ExpectError: regexp.MustCompile(`Missing Expiry Date`),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "expirable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "expires_on", "2030-01-31"),
+					resource.TestCheckResourceAttr(resourceName, "remove_upon_expiry", "false"),
+				),
+			},
+			{
+				// A mailbox that is not removed upon expiry stays in state.
+				PreConfig: func() {
+					server.RemoveExpired("2030-01-31")
+				},
+				Config:   config(false),
+				PlanOnly: true,
+			},
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr(resourceName, "remove_upon_expiry", "true"),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveExpired("2030-01-31")
+				},
+				Config:             config(true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_isInternal(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_mailbox.test"
+
+	config := func(isInternal bool) string {
+		return fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name     = "%s"
+  local_part      = "tfacc-mailbox-internal"
+  name            = "Internal"
+  password_method = "password"
+  password        = "correct-horse"
+  is_internal     = %t
+}
+`, testFakeDomain, isInternal)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr(resourceName, "is_internal", "false"),
+			},
+			{
+				Config: config(true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "is_internal", "true"),
+			},
+			{
+				// Removing is_internal from the configuration keeps the value
+				// in Migadu.
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name     = "%s"
+  local_part      = "tfacc-mailbox-internal"
+  name            = "Internal"
+  password_method = "password"
+  password        = "correct-horse"
+}
+`, testFakeDomain),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccMailboxResource_senderLists(t *testing.T) {
+	testFakeSetup(t)
+	resourceName := "migadu_mailbox.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The lists take addresses as well as bare domains, which
+				// Migadu returns in lowercase.
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name        = "%s"
+  local_part         = "tfacc-mailbox-lists"
+  name               = "Lists"
+  password_method    = "password"
+  password           = "correct-horse"
+  sender_allowlist   = ["Partner.example", "bob@example.org"]
+  sender_denylist    = ["spam.example"]
+  recipient_denylist = ["Carol@Example.org"]
+}
+`, testFakeDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(resourceName, "sender_allowlist.*", "Partner.example"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "sender_allowlist.*", "bob@example.org"),
+					resource.TestCheckResourceAttr(resourceName, "sender_denylist.0", "spam.example"),
+					resource.TestCheckResourceAttr(resourceName, "recipient_denylist.0", "Carol@Example.org"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "test" {
+  domain_name     = "%s"
+  local_part      = "tfacc-mailbox-lists"
+  name            = "Lists"
+  password_method = "password"
+  password        = "correct-horse"
+  sender_denylist = ["not a domain"]
+}
+`, testFakeDomain),
+				ExpectError: regexp.MustCompile(`not a valid email address`),
 			},
 		},
 	})
@@ -2,12 +2,18 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/MrLemur/migadu-go"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestNewMailboxResourceMetadata(t *testing.T) {
@@ -60,6 +66,36 @@ func TestMailboxResourceImportState(t *testing.T) {
 		}
 	})
 
+	t.Run("address", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "Admin@Example.com"}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected import errors: %v", resp.Diagnostics)
+		}
+
+		if got := getStateStringAttribute(t, resp.State, "domain_name"); got != "example.com" {
+			t.Fatalf("expected domain_name to be %q, got %q", "example.com", got)
+		}
+
+		if got := getStateStringAttribute(t, resp.State, "local_part"); got != "admin" {
+			t.Fatalf("expected local_part to be %q, got %q", "admin", got)
+		}
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		resp := resource.ImportStateResponse{
+			State: newStateForSchema(schemaResp.Schema),
+		}
+
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: "admin@"}, &resp)
+
+		assertHasDiagnosticSummary(t, resp.Diagnostics, "Invalid Import ID")
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		resp := resource.ImportStateResponse{
 			State: newStateForSchema(schemaResp.Schema),
@@ -81,14 +117,14 @@ func TestMailboxResourcePasswordValidation(t *testing.T) {
 
 	testCases := map[string]func(t *testing.T, plan tfsdk.Plan) diag.Diagnostics{
 		"create": func(t *testing.T, plan tfsdk.Plan) diag.Diagnostics {
-			req := resource.CreateRequest{Plan: plan}
-			resp := resource.CreateResponse{State: newStateForSchema(schemaResp.Schema)}
+			req := resource.CreateRequest{Plan: plan, Config: configFromPlan(plan)}
+			resp := resource.CreateResponse{State: newStateForSchema(schemaResp.Schema), Identity: newResourceIdentity(t, r, nil)}
 			r.Create(context.Background(), req, &resp)
 			return resp.Diagnostics
 		},
 		"update": func(t *testing.T, plan tfsdk.Plan) diag.Diagnostics {
-			req := resource.UpdateRequest{Plan: plan}
-			resp := resource.UpdateResponse{State: newStateForSchema(schemaResp.Schema)}
+			req := resource.UpdateRequest{Plan: plan, Config: configFromPlan(plan), State: newStateForSchema(schemaResp.Schema)}
+			resp := resource.UpdateResponse{State: newStateForSchema(schemaResp.Schema), Identity: newResourceIdentity(t, r, nil)}
 			r.Update(context.Background(), req, &resp)
 			return resp.Diagnostics
 		},
@@ -98,12 +134,14 @@ func TestMailboxResourcePasswordValidation(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			plan := newPlanForSchema(schemaResp.Schema)
 			diags := plan.Set(context.Background(), &MailboxResourceModel{
-				DomainName:            types.StringValue("example.com"),
-				LocalPart:             types.StringValue("admin"),
+				DomainName:            NewDomainNameValue("example.com"),
+				LocalPart:             NewLocalPartValue("admin"),
 				Name:                  types.StringValue("Admin"),
 				PasswordMethod:        types.StringValue("password"),
 				Password:              types.StringNull(),
-				PasswordRecoveryEmail: types.StringNull(),
+				PasswordWO:            types.StringNull(),
+				PasswordWOVersion:     types.Int64Null(),
+				PasswordRecoveryEmail: NewEmailAddressNull(),
 				MaySend:               types.BoolValue(true),
 				MayReceive:            types.BoolValue(true),
 				MayAccessImap:         types.BoolValue(true),
@@ -114,14 +152,15 @@ func TestMailboxResourcePasswordValidation(t *testing.T) {
 				FooterActive:          types.BoolValue(false),
 				FooterPlainBody:       types.StringNull(),
 				FooterHTMLBody:        types.StringNull(),
-				SenderAllowlist:       types.ListNull(types.StringType),
-				SenderDenylist:        types.ListNull(types.StringType),
-				RecipientDenylist:     types.ListNull(types.StringType),
-				Address:               types.StringNull(),
+				SenderAllowlist:       NewUnorderedListNull(EmailAddressType{}),
+				SenderDenylist:        NewUnorderedListNull(EmailAddressType{}),
+				RecipientDenylist:     NewUnorderedListNull(EmailAddressType{}),
+				Address:               NewEmailAddressNull(),
 				IsInternal:            types.BoolNull(),
 				StorageUsage:          types.Int64Null(),
 				ChangedAt:             types.StringNull(),
 				LastLoginAt:           types.StringNull(),
+				Timeouts:              nullTimeouts(t, schemaResp.Schema),
 			})
 			if diags.HasError() {
 				t.Fatalf("failed preparing test plan: %v", diags)
@@ -136,3 +175,134 @@ func TestMailboxResourcePasswordValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMailboxResourceValidateConfig(t *testing.T) {
+	r := NewMailboxResource().(*MailboxResource)
+	schemaResp := mustResourceSchema(t, r)
+
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	testCases := map[string]struct {
+		values         map[string]tftypes.Value
+		expectedErrors map[string]string
+	}{
+		"method unset": {
+			values: map[string]tftypes.Value{},
+		},
+		"method unset with password": {
+			values: map[string]tftypes.Value{"password": str("secret")},
+		},
+		"method unknown": {
+			values: map[string]tftypes.Value{"password_method": unknown},
+		},
+		"password with password": {
+			values: map[string]tftypes.Value{"password_method": str("password"), "password": str("secret")},
+		},
+		"password with password_wo": {
+			values: map[string]tftypes.Value{"password_method": str("password"), "password_wo": str("secret")},
+		},
+		"password with unknown password": {
+			values: map[string]tftypes.Value{"password_method": str("password"), "password": unknown},
+		},
+		"password with recovery email": {
+			values: map[string]tftypes.Value{"password_method": str("password"), "password": str("secret"), "password_recovery_email": str("owner@example.net")},
+		},
+		"password missing": {
+			values:         map[string]tftypes.Value{"password_method": str("password")},
+			expectedErrors: map[string]string{"password": "Missing Password"},
+		},
+		"invitation with recovery email": {
+			values: map[string]tftypes.Value{"password_method": str("invitation"), "password_recovery_email": str("owner@example.net")},
+		},
+		"invitation with unknown recovery email": {
+			values: map[string]tftypes.Value{"password_method": str("invitation"), "password_recovery_email": unknown},
+		},
+		"invitation missing recovery email": {
+			values:         map[string]tftypes.Value{"password_method": str("invitation")},
+			expectedErrors: map[string]string{"password_recovery_email": "Missing Password Recovery Email"},
+		},
+		"invitation with empty recovery email": {
+			values:         map[string]tftypes.Value{"password_method": str("invitation"), "password_recovery_email": str("")},
+			expectedErrors: map[string]string{"password_recovery_email": "Missing Password Recovery Email"},
+		},
+		"invitation with password": {
+			values:         map[string]tftypes.Value{"password_method": str("invitation"), "password": str("secret"), "password_recovery_email": str("owner@example.net")},
+			expectedErrors: map[string]string{"password": "Conflicting Password"},
+		},
+		"invitation with password_wo": {
+			values:         map[string]tftypes.Value{"password_method": str("invitation"), "password_wo": str("secret"), "password_recovery_email": str("owner@example.net")},
+			expectedErrors: map[string]string{"password_wo": "Conflicting Password"},
+		},
+		"invitation with password and no recovery email": {
+			values: map[string]tftypes.Value{"password_method": str("invitation"), "password": str("secret")},
+			expectedErrors: map[string]string{
+				"password":                "Conflicting Password",
+				"password_recovery_email": "Missing Password Recovery Email",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{Config: newResourceConfig(schemaResp.Schema, tc.values)}
+			var resp resource.ValidateConfigResponse
+
+			r.ValidateConfig(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != len(tc.expectedErrors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tc.expectedErrors), got, resp.Diagnostics)
+			}
+
+			for attr, summary := range tc.expectedErrors {
+				found := false
+				for _, d := range resp.Diagnostics.Errors() {
+					withPath, ok := d.(diag.DiagnosticWithPath)
+					if ok && withPath.Path().Equal(path.Root(attr)) && d.Summary() == summary {
+						found = true
+					}
+				}
+				if !found {
+					t.Fatalf("expected %q error on %s, got %v", summary, attr, resp.Diagnostics)
+				}
+			}
+		})
+	}
+}
+
+func TestMailboxResourceReadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := migadu.New("admin@example.com", "api-key", migadu.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed creating client: %s", err)
+	}
+
+	r := &MailboxResource{client: client}
+	schemaResp := mustResourceSchema(t, r)
+
+	ctx := context.Background()
+	state := newStateForSchema(schemaResp.Schema)
+	for name, value := range map[string]string{"domain_name": "example.com", "local_part": "admin"} {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("failed preparing state: %v", diags)
+		}
+	}
+	if diags := state.SetAttribute(ctx, path.Root("timeouts").AtName("read"), "100ms"); diags.HasError() {
+		t.Fatalf("failed preparing state: %v", diags)
+	}
+
+	resp := resource.ReadResponse{State: state, Identity: newResourceIdentity(t, r, nil)}
+	start := time.Now()
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected read against a hung API to fail, got no error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the read timeout to abort the request, took %s", elapsed)
+	}
+}
@@ -22,8 +22,9 @@ type MailboxesDataSource struct {
 }
 
 type MailboxesDataSourceModel struct {
-	DomainName types.String `tfsdk:"domain_name"`
-	Mailboxes  types.List   `tfsdk:"mailboxes"`
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	IsInternal types.Bool      `tfsdk:"is_internal"`
+	Mailboxes  types.List      `tfsdk:"mailboxes"`
 }
 
 type MailboxListItemModel struct {
@@ -31,6 +32,9 @@ type MailboxListItemModel struct {
 	Name                 types.String  `tfsdk:"name"`
 	Address              types.String  `tfsdk:"address"`
 	IsInternal           types.Bool    `tfsdk:"is_internal"`
+	Expirable            types.Bool    `tfsdk:"expirable"`
+	ExpiresOn            types.String  `tfsdk:"expires_on"`
+	RemoveUponExpiry     types.Bool    `tfsdk:"remove_upon_expiry"`
 	MaySend              types.Bool    `tfsdk:"may_send"`
 	MayReceive           types.Bool    `tfsdk:"may_receive"`
 	MayAccessImap        types.Bool    `tfsdk:"may_access_imap"`
@@ -54,8 +58,13 @@ func (d *MailboxesDataSource) Schema(ctx context.Context, req datasource.SchemaR
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
+			"is_internal": schema.BoolAttribute{
+				MarkdownDescription: "If set, only mailboxes whose `is_internal` matches are returned.",
+				Optional:            true,
+			},
 			"mailboxes": schema.ListNestedAttribute{
 				MarkdownDescription: "List of mailboxes for this domain.",
 				Computed:            true,
@@ -71,6 +80,18 @@ func (d *MailboxesDataSource) Schema(ctx context.Context, req datasource.SchemaR
 							MarkdownDescription: "Whether this is an internal mailbox.",
 							Computed:            true,
 						},
+						"expirable": schema.BoolAttribute{
+							MarkdownDescription: "Whether the mailbox expires.",
+							Computed:            true,
+						},
+						"expires_on": schema.StringAttribute{
+							MarkdownDescription: "Date on which the mailbox expires.",
+							Computed:            true,
+						},
+						"remove_upon_expiry": schema.BoolAttribute{
+							MarkdownDescription: "Whether the mailbox is removed once it expires.",
+							Computed:            true,
+						},
 						"may_send":    schema.BoolAttribute{MarkdownDescription: "Whether the mailbox can send emails.", Computed: true},
 						"may_receive": schema.BoolAttribute{MarkdownDescription: "Whether the mailbox can receive emails.", Computed: true},
 						"may_access_imap": schema.BoolAttribute{
@@ -92,19 +113,19 @@ func (d *MailboxesDataSource) Schema(ctx context.Context, req datasource.SchemaR
 						"changed_at":    schema.StringAttribute{MarkdownDescription: "Last modification timestamp.", Computed: true},
 						"last_login_at": schema.StringAttribute{MarkdownDescription: "Last login timestamp.", Computed: true},
 						"sender_allowlist": schema.ListAttribute{
-							MarkdownDescription: "List of allowed sender addresses.",
+							MarkdownDescription: "List of allowed sender addresses or domains.",
 							Computed:            true,
-							ElementType:         types.StringType,
+							ElementType:         AddressOrDomainType{},
 						},
 						"sender_denylist": schema.ListAttribute{
-							MarkdownDescription: "List of denied sender addresses.",
+							MarkdownDescription: "List of denied sender addresses or domains.",
 							Computed:            true,
-							ElementType:         types.StringType,
+							ElementType:         AddressOrDomainType{},
 						},
 						"recipient_denylist": schema.ListAttribute{
-							MarkdownDescription: "List of denied recipient addresses.",
+							MarkdownDescription: "List of denied recipient addresses or domains.",
 							Computed:            true,
-							ElementType:         types.StringType,
+							ElementType:         AddressOrDomainType{},
 						},
 					},
 				},
@@ -137,20 +158,24 @@ func (d *MailboxesDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	mailboxes, err := d.client.ListMailboxes(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list mailboxes, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "list mailboxes", err)
 		return
 	}
 
 	items := make([]MailboxListItemModel, 0, len(mailboxes))
 	for _, mailbox := range mailboxes {
-		senderAllowlist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.SenderAllowlist))
+		if !data.IsInternal.IsNull() && mailbox.IsInternal != data.IsInternal.ValueBool() {
+			continue
+		}
+
+		senderAllowlist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(mailbox.SenderAllowlist))
 		resp.Diagnostics.Append(diags...)
-		senderDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.SenderDenylist))
+		senderDenylist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(mailbox.SenderDenylist))
 		resp.Diagnostics.Append(diags...)
-		recipientDenylist, diags := types.ListValueFrom(ctx, types.StringType, normalizeStringSlice(mailbox.RecipientDenylist))
+		recipientDenylist, diags := types.ListValueFrom(ctx, AddressOrDomainType{}, normalizeStringSlice(mailbox.RecipientDenylist))
 		resp.Diagnostics.Append(diags...)
 
 		items = append(items, MailboxListItemModel{
@@ -158,6 +183,9 @@ func (d *MailboxesDataSource) Read(ctx context.Context, req datasource.ReadReque
 			Name:                 types.StringValue(mailbox.Name),
 			Address:              types.StringValue(mailbox.Address),
 			IsInternal:           types.BoolValue(mailbox.IsInternal),
+			Expirable:            types.BoolValue(mailbox.Expireable),
+			ExpiresOn:            types.StringValue(mailbox.ExpiresOn),
+			RemoveUponExpiry:     types.BoolValue(mailbox.RemoveUponExpiry),
 			MaySend:              types.BoolValue(mailbox.MaySend),
 			MayReceive:           types.BoolValue(mailbox.MayReceive),
 			MayAccessImap:        types.BoolValue(mailbox.MayAccessImap),
@@ -178,6 +206,9 @@ func (d *MailboxesDataSource) Read(ctx context.Context, req datasource.ReadReque
 			"name":                   types.StringType,
 			"address":                types.StringType,
 			"is_internal":            types.BoolType,
+			"expirable":              types.BoolType,
+			"expires_on":             types.StringType,
+			"remove_upon_expiry":     types.BoolType,
 			"may_send":               types.BoolType,
 			"may_receive":            types.BoolType,
 			"may_access_imap":        types.BoolType,
@@ -186,9 +217,9 @@ func (d *MailboxesDataSource) Read(ctx context.Context, req datasource.ReadReque
 			"storage_usage":          types.Float64Type,
 			"changed_at":             types.StringType,
 			"last_login_at":          types.StringType,
-			"sender_allowlist":       types.ListType{ElemType: types.StringType},
-			"sender_denylist":        types.ListType{ElemType: types.StringType},
-			"recipient_denylist":     types.ListType{ElemType: types.StringType},
+			"sender_allowlist":       types.ListType{ElemType: AddressOrDomainType{}},
+			"sender_denylist":        types.ListType{ElemType: AddressOrDomainType{}},
+			"recipient_denylist":     types.ListType{ElemType: AddressOrDomainType{}},
 		},
 	}, items)
 	resp.Diagnostics.Append(diags...)
@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewMailboxesDataSourceMetadata(t *testing.T) {
@@ -50,3 +53,67 @@ func TestNewMailboxesDataSourceSchemaExpectations(t *testing.T) {
 		t.Fatal("expected mailboxes.storage_usage to be a computed Float64Attribute")
 	}
 }
+
+func TestAccMailboxesDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	localPart := fmt.Sprintf("tfacc-mailbox-ds-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailboxConfig(domainName, localPart) + `
+data "migadu_mailboxes" "test" {
+  domain_name = migadu_mailbox.test.domain_name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.migadu_mailboxes.test", "mailboxes.*", map[string]string{
+						"local_part": localPart,
+						"address":    localPart + "@" + domainName,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMailboxesDataSource_isInternal(t *testing.T) {
+	testFakeSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "migadu_mailbox" "internal" {
+  domain_name     = "%[1]s"
+  local_part      = "intranet"
+  name            = "Intranet"
+  password_method = "password"
+  password        = "correct-horse"
+  is_internal     = true
+}
+
+resource "migadu_mailbox" "public" {
+  domain_name     = "%[1]s"
+  local_part      = "info"
+  name            = "Info"
+  password_method = "password"
+  password        = "correct-horse"
+}
+
+data "migadu_mailboxes" "internal" {
+  domain_name = "%[1]s"
+  is_internal = true
+  depends_on  = [migadu_mailbox.internal, migadu_mailbox.public]
+}
+`, testFakeDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_mailboxes.internal", "mailboxes.#", "1"),
+					resource.TestCheckResourceAttr("data.migadu_mailboxes.internal", "mailboxes.0.local_part", "intranet"),
+				),
+			},
+		},
+	})
+}
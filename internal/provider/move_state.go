@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// metioProviderAddress is the address of the other community Migadu
+// provider. Its migadu_mailbox, migadu_alias, migadu_identity and
+// migadu_rewrite resources can be moved to this provider's with moved blocks.
+const metioProviderAddress = "registry.terraform.io/metio/migadu"
+
+// moveStateFromMetio returns a state mover for the resource of the given
+// type in the metio provider. The source state is read with sourceSchema,
+// which only declares the attributes carried over, and move returns the
+// target attributes to set. The identity is set from identityAttributes,
+// which must be among them. Attributes the source lacks are left null and
+// filled in by the refresh that follows the move.
+func moveStateFromMetio(typeName string, sourceSchema schema.Schema, identityAttributes []string, move func(ctx context.Context, source *tfsdk.State) (map[string]attr.Value, diag.Diagnostics)) resource.StateMover {
+	return resource.StateMover{
+		SourceSchema: &sourceSchema,
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if req.SourceProviderAddress != metioProviderAddress || req.SourceTypeName != typeName {
+				return
+			}
+
+			if req.SourceState == nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					"The state of "+typeName+" from "+metioProviderAddress+" could not be read. "+
+						"It may have been written by a version of that provider that is not supported. Please report this issue to the provider developers.",
+				)
+				return
+			}
+
+			values, diags := move(ctx, req.SourceState)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			for name, value := range values {
+				resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root(name), value)...)
+			}
+
+			if resp.TargetIdentity == nil {
+				return
+			}
+			for _, name := range identityAttributes {
+				resp.Diagnostics.Append(resp.TargetIdentity.SetAttribute(ctx, path.Root(name), values[name])...)
+			}
+		},
+	}
+}
+
+// metioMailboxModel holds the attributes carried over from a metio
+// migadu_mailbox.
+type metioMailboxModel struct {
+	DomainName            types.String `tfsdk:"domain_name"`
+	LocalPart             types.String `tfsdk:"local_part"`
+	Name                  types.String `tfsdk:"name"`
+	Password              types.String `tfsdk:"password"`
+	PasswordRecoveryEmail types.String `tfsdk:"password_recovery_email"`
+	MaySend               types.Bool   `tfsdk:"may_send"`
+	MayReceive            types.Bool   `tfsdk:"may_receive"`
+	MayAccessImap         types.Bool   `tfsdk:"may_access_imap"`
+	MayAccessPop3         types.Bool   `tfsdk:"may_access_pop3"`
+	MayAccessManageSieve  types.Bool   `tfsdk:"may_access_manage_sieve"`
+	SpamAction            types.String `tfsdk:"spam_action"`
+	SpamAggressiveness    types.String `tfsdk:"spam_aggressiveness"`
+	SenderAllowlist       types.List   `tfsdk:"sender_allowlist"`
+	SenderDenylist        types.List   `tfsdk:"sender_denylist"`
+	RecipientDenylist     types.List   `tfsdk:"recipient_denylist"`
+	FooterActive          types.Bool   `tfsdk:"footer_active"`
+	FooterPlainBody       types.String `tfsdk:"footer_plain_body"`
+	FooterHTMLBody        types.String `tfsdk:"footer_html_body"`
+	Address               types.String `tfsdk:"address"`
+	IsInternal            types.Bool   `tfsdk:"is_internal"`
+	Expirable             types.Bool   `tfsdk:"expirable"`
+	ExpiresOn             types.String `tfsdk:"expires_on"`
+	RemoveUponExpiry      types.Bool   `tfsdk:"remove_upon_expiry"`
+}
+
+func metioMailboxSchema() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name":             schema.StringAttribute{Required: true},
+			"local_part":              schema.StringAttribute{Required: true},
+			"name":                    schema.StringAttribute{Optional: true},
+			"password":                schema.StringAttribute{Optional: true, Sensitive: true},
+			"password_recovery_email": schema.StringAttribute{Optional: true},
+			"may_send":                schema.BoolAttribute{Optional: true},
+			"may_receive":             schema.BoolAttribute{Optional: true},
+			"may_access_imap":         schema.BoolAttribute{Optional: true},
+			"may_access_pop3":         schema.BoolAttribute{Optional: true},
+			"may_access_manage_sieve": schema.BoolAttribute{Optional: true},
+			"spam_action":             schema.StringAttribute{Optional: true},
+			"spam_aggressiveness":     schema.StringAttribute{Optional: true},
+			"sender_allowlist":        schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"sender_denylist":         schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"recipient_denylist":      schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"footer_active":           schema.BoolAttribute{Optional: true},
+			"footer_plain_body":       schema.StringAttribute{Optional: true},
+			"footer_html_body":        schema.StringAttribute{Optional: true},
+			"address":                 schema.StringAttribute{Computed: true},
+			"is_internal":             schema.BoolAttribute{Computed: true},
+			"expirable":               schema.BoolAttribute{Optional: true},
+			"expires_on":              schema.StringAttribute{Optional: true},
+			"remove_upon_expiry":      schema.BoolAttribute{Optional: true},
+		},
+	}
+}
+
+func moveMailboxFromMetio(ctx context.Context, source *tfsdk.State) (map[string]attr.Value, diag.Diagnostics) {
+	var data metioMailboxModel
+	diags := source.Get(ctx, &data)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// The metio provider has no password_method. A mailbox with a password
+	// was created with one, and one with only a recovery email by invitation.
+	passwordMethod := types.StringNull()
+	if data.Password.ValueString() != "" {
+		passwordMethod = types.StringValue("password")
+	} else if data.PasswordRecoveryEmail.ValueString() != "" {
+		passwordMethod = types.StringValue("invitation")
+	}
+
+	return map[string]attr.Value{
+		"domain_name":             data.DomainName,
+		"local_part":              data.LocalPart,
+		"name":                    data.Name,
+		"password_method":         passwordMethod,
+		"password":                data.Password,
+		"password_recovery_email": data.PasswordRecoveryEmail,
+		"may_send":                data.MaySend,
+		"may_receive":             data.MayReceive,
+		"may_access_imap":         data.MayAccessImap,
+		"may_access_pop3":         data.MayAccessPop3,
+		"may_access_managesieve":  data.MayAccessManageSieve,
+		"spam_action":             data.SpamAction,
+		"spam_aggressiveness":     data.SpamAggressiveness,
+		"sender_allowlist":        data.SenderAllowlist,
+		"sender_denylist":         data.SenderDenylist,
+		"recipient_denylist":      data.RecipientDenylist,
+		"footer_active":           data.FooterActive,
+		"footer_plain_body":       data.FooterPlainBody,
+		"footer_html_body":        data.FooterHTMLBody,
+		"address":                 data.Address,
+		"is_internal":             data.IsInternal,
+		"expirable":               data.Expirable,
+		"expires_on":              data.ExpiresOn,
+		"remove_upon_expiry":      data.RemoveUponExpiry,
+		"adopt_existing":          types.BoolValue(false),
+	}, diags
+}
+
+// metioAliasModel holds the attributes carried over from a metio
+// migadu_alias.
+type metioAliasModel struct {
+	DomainName       types.String `tfsdk:"domain_name"`
+	LocalPart        types.String `tfsdk:"local_part"`
+	Destinations     types.List   `tfsdk:"destinations"`
+	Address          types.String `tfsdk:"address"`
+	IsInternal       types.Bool   `tfsdk:"is_internal"`
+	Expirable        types.Bool   `tfsdk:"expirable"`
+	ExpiresOn        types.String `tfsdk:"expires_on"`
+	RemoveUponExpiry types.Bool   `tfsdk:"remove_upon_expiry"`
+}
+
+func metioAliasSchema() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name":        schema.StringAttribute{Required: true},
+			"local_part":         schema.StringAttribute{Required: true},
+			"destinations":       schema.ListAttribute{ElementType: types.StringType, Required: true},
+			"address":            schema.StringAttribute{Computed: true},
+			"is_internal":        schema.BoolAttribute{Optional: true},
+			"expirable":          schema.BoolAttribute{Optional: true},
+			"expires_on":         schema.StringAttribute{Optional: true},
+			"remove_upon_expiry": schema.BoolAttribute{Optional: true},
+		},
+	}
+}
+
+func moveAliasFromMetio(ctx context.Context, source *tfsdk.State) (map[string]attr.Value, diag.Diagnostics) {
+	var data metioAliasModel
+	diags := source.Get(ctx, &data)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return map[string]attr.Value{
+		"domain_name":        data.DomainName,
+		"local_part":         data.LocalPart,
+		"destinations":       data.Destinations,
+		"address":            data.Address,
+		"is_internal":        data.IsInternal,
+		"expirable":          data.Expirable,
+		"expires_on":         data.ExpiresOn,
+		"remove_upon_expiry": data.RemoveUponExpiry,
+		"adopt_existing":     types.BoolValue(false),
+	}, diags
+}
+
+// metioIdentityModel holds the attributes carried over from a metio
+// migadu_identity. The metio provider names the mailbox local_part and the
+// identity's own local part identity.
+type metioIdentityModel struct {
+	DomainName           types.String `tfsdk:"domain_name"`
+	LocalPart            types.String `tfsdk:"local_part"`
+	Identity             types.String `tfsdk:"identity"`
+	Name                 types.String `tfsdk:"name"`
+	Password             types.String `tfsdk:"password"`
+	MaySend              types.Bool   `tfsdk:"may_send"`
+	MayReceive           types.Bool   `tfsdk:"may_receive"`
+	MayAccessImap        types.Bool   `tfsdk:"may_access_imap"`
+	MayAccessPop3        types.Bool   `tfsdk:"may_access_pop3"`
+	MayAccessManageSieve types.Bool   `tfsdk:"may_access_manage_sieve"`
+	Address              types.String `tfsdk:"address"`
+}
+
+func metioIdentitySchema() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name":             schema.StringAttribute{Required: true},
+			"local_part":              schema.StringAttribute{Required: true},
+			"identity":                schema.StringAttribute{Required: true},
+			"name":                    schema.StringAttribute{Optional: true},
+			"password":                schema.StringAttribute{Optional: true, Sensitive: true},
+			"may_send":                schema.BoolAttribute{Optional: true},
+			"may_receive":             schema.BoolAttribute{Optional: true},
+			"may_access_imap":         schema.BoolAttribute{Optional: true},
+			"may_access_pop3":         schema.BoolAttribute{Optional: true},
+			"may_access_manage_sieve": schema.BoolAttribute{Optional: true},
+			"address":                 schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+func moveIdentityFromMetio(ctx context.Context, source *tfsdk.State) (map[string]attr.Value, diag.Diagnostics) {
+	var data metioIdentityModel
+	diags := source.Get(ctx, &data)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return map[string]attr.Value{
+		"domain_name":            data.DomainName,
+		"mailbox":                data.LocalPart,
+		"local_part":             data.Identity,
+		"name":                   data.Name,
+		"password":               data.Password,
+		"may_send":               data.MaySend,
+		"may_receive":            data.MayReceive,
+		"may_access_imap":        data.MayAccessImap,
+		"may_access_pop3":        data.MayAccessPop3,
+		"may_access_managesieve": data.MayAccessManageSieve,
+		"address":                data.Address,
+		"adopt_existing":         types.BoolValue(false),
+	}, diags
+}
+
+// metioRewriteModel holds the attributes carried over from a metio
+// migadu_rewrite.
+type metioRewriteModel struct {
+	DomainName    types.String `tfsdk:"domain_name"`
+	Name          types.String `tfsdk:"name"`
+	LocalPartRule types.String `tfsdk:"local_part_rule"`
+	OrderNum      types.Int64  `tfsdk:"order_num"`
+	Destinations  types.List   `tfsdk:"destinations"`
+}
+
+func metioRewriteSchema() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name":     schema.StringAttribute{Required: true},
+			"name":            schema.StringAttribute{Required: true},
+			"local_part_rule": schema.StringAttribute{Required: true},
+			"order_num":       schema.Int64Attribute{Optional: true},
+			"destinations":    schema.ListAttribute{ElementType: types.StringType, Required: true},
+		},
+	}
+}
+
+func moveRewriteFromMetio(ctx context.Context, source *tfsdk.State) (map[string]attr.Value, diag.Diagnostics) {
+	var data metioRewriteModel
+	diags := source.Get(ctx, &data)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return map[string]attr.Value{
+		"domain_name":     data.DomainName,
+		"name":            data.Name,
+		"local_part_rule": data.LocalPartRule,
+		"order_num":       data.OrderNum,
+		"destinations":    data.Destinations,
+		"adopt_existing":  types.BoolValue(false),
+	}, diags
+}
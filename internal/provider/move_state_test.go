@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMoveResourceStateFromMetio(t *testing.T) {
+	testCases := map[string]struct {
+		resource         resource.Resource
+		typeName         string
+		fixture          string
+		expected         map[string]tftypes.Value
+		expectedIdentity map[string]string
+	}{
+		"mailbox": {
+			resource: NewMailboxResource(),
+			typeName: "migadu_mailbox",
+			fixture:  "mailbox.json",
+			expected: map[string]tftypes.Value{
+				"domain_name":            tftypes.NewValue(tftypes.String, "example.com"),
+				"local_part":             tftypes.NewValue(tftypes.String, "alice"),
+				"address":                tftypes.NewValue(tftypes.String, "alice@example.com"),
+				"password_method":        tftypes.NewValue(tftypes.String, "password"),
+				"password":               tftypes.NewValue(tftypes.String, "correct-horse"),
+				"may_access_pop3":        tftypes.NewValue(tftypes.Bool, false),
+				"may_access_managesieve": tftypes.NewValue(tftypes.Bool, false),
+				"adopt_existing":         tftypes.NewValue(tftypes.Bool, false),
+				"storage_usage":          tftypes.NewValue(tftypes.Number, nil),
+				"sender_allowlist": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "bob@example.org"),
+				}),
+			},
+			expectedIdentity: map[string]string{"domain_name": "example.com", "local_part": "alice"},
+		},
+		"alias": {
+			resource: NewAliasResource(),
+			typeName: "migadu_alias",
+			fixture:  "alias.json",
+			expected: map[string]tftypes.Value{
+				"local_part": tftypes.NewValue(tftypes.String, "sales"),
+				"destinations": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "alice@example.com"),
+					tftypes.NewValue(tftypes.String, "bob@example.com"),
+				}),
+				"expirable":      tftypes.NewValue(tftypes.Bool, true),
+				"expires_on":     tftypes.NewValue(tftypes.String, "2030-01-31"),
+				"adopt_existing": tftypes.NewValue(tftypes.Bool, false),
+			},
+			expectedIdentity: map[string]string{"domain_name": "example.com", "local_part": "sales"},
+		},
+		"identity": {
+			resource: NewIdentityResource(),
+			typeName: "migadu_identity",
+			fixture:  "identity.json",
+			expected: map[string]tftypes.Value{
+				"mailbox":     tftypes.NewValue(tftypes.String, "alice"),
+				"local_part":  tftypes.NewValue(tftypes.String, "support"),
+				"address":     tftypes.NewValue(tftypes.String, "support@example.com"),
+				"may_receive": tftypes.NewValue(tftypes.Bool, false),
+				"password":    tftypes.NewValue(tftypes.String, nil),
+			},
+			expectedIdentity: map[string]string{"domain_name": "example.com", "mailbox": "alice", "local_part": "support"},
+		},
+		"rewrite": {
+			resource: NewRewriteResource(),
+			typeName: "migadu_rewrite",
+			fixture:  "rewrite.json",
+			expected: map[string]tftypes.Value{
+				"name":            tftypes.NewValue(tftypes.String, "catch-support"),
+				"local_part_rule": tftypes.NewValue(tftypes.String, "support-*"),
+				"order_num":       tftypes.NewValue(tftypes.Number, 1),
+			},
+			expectedIdentity: map[string]string{"domain_name": "example.com", "name": "catch-support"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			resp := moveResourceState(t, tc.typeName, metioProviderAddress, tc.typeName, readMetioFixture(t, tc.fixture))
+			for _, diagnostic := range resp.Diagnostics {
+				if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+			}
+
+			state, err := resp.TargetState.Unmarshal(mustResourceSchema(t, tc.resource).Schema.Type().TerraformType(ctx))
+			if err != nil {
+				t.Fatalf("failed reading moved state: %s", err)
+			}
+
+			var attributes map[string]tftypes.Value
+			if err := state.As(&attributes); err != nil {
+				t.Fatalf("failed reading moved state: %s", err)
+			}
+			for attribute, expected := range tc.expected {
+				if !attributes[attribute].Equal(expected) {
+					t.Errorf("expected %s to be %s, got %s", attribute, expected, attributes[attribute])
+				}
+			}
+
+			if resp.TargetIdentity == nil {
+				t.Fatal("expected a moved identity")
+			}
+			identity := newResourceIdentity(t, tc.resource, tc.expectedIdentity)
+			got, err := resp.TargetIdentity.IdentityData.Unmarshal(identity.Schema.Type().TerraformType(ctx))
+			if err != nil {
+				t.Fatalf("failed reading moved identity: %s", err)
+			}
+			if !got.Equal(identity.Raw) {
+				t.Errorf("expected identity %s, got %s", identity.Raw, got)
+			}
+		})
+	}
+}
+
+func TestMoveResourceStateUnsupportedSource(t *testing.T) {
+	testCases := map[string]struct {
+		sourceProvider string
+		sourceType     string
+	}{
+		"other provider": {sourceProvider: "registry.terraform.io/hashicorp/null", sourceType: "migadu_alias"},
+		"other type":     {sourceProvider: metioProviderAddress, sourceType: "migadu_mailbox"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := moveResourceState(t, "migadu_alias", tc.sourceProvider, tc.sourceType, readMetioFixture(t, "alias.json"))
+
+			if resp.TargetState != nil {
+				t.Fatal("expected no moved state")
+			}
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Unable to Move Resource State" {
+				t.Fatalf("expected an Unable to Move Resource State error, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func moveResourceState(t *testing.T, targetType, sourceProvider, sourceType string, rawState []byte) *tfprotov6.MoveResourceStateResponse {
+	t.Helper()
+
+	server, err := testAccProtoV6ProviderFactories["migadu"]()
+	if err != nil {
+		t.Fatalf("failed creating provider server: %s", err)
+	}
+
+	resp, err := server.MoveResourceState(context.Background(), &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: sourceProvider,
+		SourceTypeName:        sourceType,
+		SourceSchemaVersion:   0,
+		SourceState:           &tfprotov6.RawState{JSON: rawState},
+		TargetTypeName:        targetType,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return resp
+}
+
+func readMetioFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "metio", name))
+	if err != nil {
+		t.Fatalf("failed reading fixture: %s", err)
+	}
+	return data
+}
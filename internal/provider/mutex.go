@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"strings"
+	"sync"
+)
+
+// domainLocks serializes writes to the same Migadu domain across all
+// resources. Migadu applies domain updates wholesale (for example the
+// catch-all and allow/deny lists), so concurrent writes to one domain can
+// conflict, while writes to different domains are independent.
+var domainLocks = newMutexKV()
+
+// mutexKV is a set of mutexes keyed by string, created on first use.
+type mutexKV struct {
+	mu    sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{store: map[string]*sync.Mutex{}}
+}
+
+// Lock acquires the mutex for key. Keys are case-insensitive.
+func (m *mutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+// Unlock releases the mutex for key.
+func (m *mutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	key = strings.ToLower(key)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMutexKVSerializesSameKey(t *testing.T) {
+	m := newMutexKV()
+	m.Lock("example.com")
+
+	acquired := make(chan struct{})
+	go func() {
+		m.Lock("EXAMPLE.com")
+		close(acquired)
+		m.Unlock("example.com")
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("expected lock on the same domain to block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	m.Unlock("example.com")
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("expected lock to be acquired after unlock")
+	}
+}
+
+func TestMutexKVDifferentKeysDoNotBlock(t *testing.T) {
+	m := newMutexKV()
+	m.Lock("example.com")
+	defer m.Unlock("example.com")
+
+	acquired := make(chan struct{})
+	go func() {
+		m.Lock("example.org")
+		close(acquired)
+		m.Unlock("example.org")
+	}()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("expected lock on a different domain not to block")
+	}
+}
@@ -2,18 +2,24 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/MrLemur/migadu-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure MigaduProvider satisfies various provider interfaces.
 var _ provider.Provider = &MigaduProvider{}
+var _ provider.ProviderWithEphemeralResources = &MigaduProvider{}
 
 // MigaduProvider defines the provider implementation.
 type MigaduProvider struct {
@@ -25,8 +31,12 @@ type MigaduProvider struct {
 
 // MigaduProviderModel describes the provider data model.
 type MigaduProviderModel struct {
-	Username types.String `tfsdk:"username"`
-	APIKey   types.String `tfsdk:"api_key"`
+	Username              types.String `tfsdk:"username"`
+	APIKey                types.String `tfsdk:"api_key"`
+	Endpoint              types.String `tfsdk:"endpoint"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait          types.Int64  `tfsdk:"retry_max_wait"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
 }
 
 func (p *MigaduProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,6 +57,32 @@ func (p *MigaduProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the Migadu API. Defaults to `https://api.migadu.com/v1`. Can also be set via the MIGADU_ENDPOINT environment variable.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a Migadu API request is retried after a rate limit (HTTP 429), a transient server error or a network error. Set to `0` to disable retries. Defaults to `4`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of seconds to wait between retries. Retries back off exponentially with jitter up to this limit. A `Retry-After` header asking for a longer wait is not retried. Defaults to `30`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of Migadu API requests the provider sends at once, regardless of Terraform's `-parallelism`. Unlimited when not set. " +
+					"Writes to the same domain are always sent one at a time.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -63,6 +99,18 @@ func (p *MigaduProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	// Configuration values are now available.
 	username := config.Username.ValueString()
 	apiKey := config.APIKey.ValueString()
+	endpoint := config.Endpoint.ValueString()
+
+	// Values that are unknown until another resource is applied, such as
+	// credentials read from a secrets manager, can't configure a client yet.
+	// If Terraform supports deferred actions, let it defer everything using
+	// this provider to a later plan instead of failing.
+	if req.ClientCapabilities.DeferralAllowed && config.hasUnknownValues() {
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+		return
+	}
 
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
@@ -83,6 +131,38 @@ func (p *MigaduProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.Endpoint.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Migadu Endpoint",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_ENDPOINT environment variable.",
+		)
+	}
+
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Migadu Max Retries",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for max_retries. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RetryMaxWait.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Migadu Retry Max Wait",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for retry_max_wait. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Migadu Max Concurrent Requests",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for max_concurrent_requests. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -97,6 +177,10 @@ func (p *MigaduProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		apiKey = os.Getenv("MIGADU_API_KEY")
 	}
 
+	if endpoint == "" {
+		endpoint = os.Getenv("MIGADU_ENDPOINT")
+	}
+
 	if username == "" {
 		resp.Diagnostics.AddError(
 			"Missing Migadu Username",
@@ -119,8 +203,32 @@ func (p *MigaduProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	maxRetries := defaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	retryMaxWait := defaultRetryMaxWait
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWait = time.Duration(config.RetryMaxWait.ValueInt64()) * time.Second
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport
+	if !config.MaxConcurrentRequests.IsNull() {
+		transport = newLimitTransport(transport, int(config.MaxConcurrentRequests.ValueInt64()))
+	}
+
+	opts := []migadu.Option{
+		migadu.WithHTTPClient(&http.Client{
+			Transport: newRetryTransport(transport, maxRetries, retryMaxWait),
+		}),
+	}
+	if endpoint != "" {
+		opts = append(opts, migadu.WithBaseURL(endpoint))
+	}
+
 	// Create a new Migadu client using the configuration values
-	client, err := migadu.New(username, apiKey)
+	client, err := migadu.New(username, apiKey, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Migadu API Client",
@@ -131,10 +239,21 @@ func (p *MigaduProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Make the Migadu client available during DataSource and Resource
-	// type Configure methods.
+	// Make the Migadu client available during DataSource, Resource and
+	// EphemeralResource type Configure methods.
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// hasUnknownValues reports whether any configuration value is unknown.
+func (m MigaduProviderModel) hasUnknownValues() bool {
+	return m.Username.IsUnknown() ||
+		m.APIKey.IsUnknown() ||
+		m.Endpoint.IsUnknown() ||
+		m.MaxRetries.IsUnknown() ||
+		m.RetryMaxWait.IsUnknown() ||
+		m.MaxConcurrentRequests.IsUnknown()
 }
 
 func (p *MigaduProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -145,6 +264,15 @@ func (p *MigaduProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewAliasResource,
 		NewIdentityResource,
 		NewRewriteResource,
+		NewForwardingResource,
+		NewMailboxAutoresponderResource,
+		NewDomainCatchallResource,
+	}
+}
+
+func (p *MigaduProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewMailboxPasswordEphemeralResource,
 	}
 }
 
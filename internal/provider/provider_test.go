@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	frameworkprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -15,63 +16,184 @@ func TestMigaduProviderConfigure(t *testing.T) {
 	testCases := map[string]struct {
 		username             tftypes.Value
 		apiKey               tftypes.Value
+		endpoint             tftypes.Value
+		maxRetries           tftypes.Value
+		retryMaxWait         tftypes.Value
+		maxConcurrent        tftypes.Value
 		envUsername          string
 		envAPIKey            string
+		envEndpoint          string
+		deferralAllowed      bool
 		expectedErrSummaries []string
 		expectClient         bool
+		expectDeferred       bool
 	}{
 		"missing username": {
-			username: tftypes.NewValue(tftypes.String, nil),
-			apiKey:   tftypes.NewValue(tftypes.String, "api-key"),
+			username:      tftypes.NewValue(tftypes.String, nil),
+			apiKey:        tftypes.NewValue(tftypes.String, "api-key"),
+			endpoint:      tftypes.NewValue(tftypes.String, nil),
+			maxRetries:    tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:  tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent: tftypes.NewValue(tftypes.Number, nil),
 			expectedErrSummaries: []string{
 				"Missing Migadu Username",
 			},
 		},
 		"missing api key": {
-			username: tftypes.NewValue(tftypes.String, "admin@example.com"),
-			apiKey:   tftypes.NewValue(tftypes.String, nil),
+			username:      tftypes.NewValue(tftypes.String, "admin@example.com"),
+			apiKey:        tftypes.NewValue(tftypes.String, nil),
+			endpoint:      tftypes.NewValue(tftypes.String, nil),
+			maxRetries:    tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:  tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent: tftypes.NewValue(tftypes.Number, nil),
 			expectedErrSummaries: []string{
 				"Missing Migadu API Key",
 			},
 		},
 		"env fallback": {
-			username:     tftypes.NewValue(tftypes.String, nil),
-			apiKey:       tftypes.NewValue(tftypes.String, nil),
-			envUsername:  "admin@example.com",
-			envAPIKey:    "env-api-key",
-			expectClient: true,
+			username:      tftypes.NewValue(tftypes.String, nil),
+			apiKey:        tftypes.NewValue(tftypes.String, nil),
+			endpoint:      tftypes.NewValue(tftypes.String, nil),
+			maxRetries:    tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:  tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent: tftypes.NewValue(tftypes.Number, nil),
+			envUsername:   "admin@example.com",
+			envAPIKey:     "env-api-key",
+			expectClient:  true,
+		},
+		"custom endpoint": {
+			username:      tftypes.NewValue(tftypes.String, "admin@example.com"),
+			apiKey:        tftypes.NewValue(tftypes.String, "api-key"),
+			endpoint:      tftypes.NewValue(tftypes.String, "http://127.0.0.1:8080/v1"),
+			maxRetries:    tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:  tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent: tftypes.NewValue(tftypes.Number, nil),
+			expectClient:  true,
+		},
+		"env endpoint": {
+			username:      tftypes.NewValue(tftypes.String, "admin@example.com"),
+			apiKey:        tftypes.NewValue(tftypes.String, "api-key"),
+			endpoint:      tftypes.NewValue(tftypes.String, nil),
+			maxRetries:    tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:  tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent: tftypes.NewValue(tftypes.Number, nil),
+			envEndpoint:   "http://127.0.0.1:8080/v1",
+			expectClient:  true,
+		},
+		"retry settings": {
+			username:      tftypes.NewValue(tftypes.String, "admin@example.com"),
+			apiKey:        tftypes.NewValue(tftypes.String, "api-key"),
+			endpoint:      tftypes.NewValue(tftypes.String, nil),
+			maxRetries:    tftypes.NewValue(tftypes.Number, 0),
+			retryMaxWait:  tftypes.NewValue(tftypes.Number, 5),
+			maxConcurrent: tftypes.NewValue(tftypes.Number, nil),
+			expectClient:  true,
+		},
+		"concurrency limit": {
+			username:      tftypes.NewValue(tftypes.String, "admin@example.com"),
+			apiKey:        tftypes.NewValue(tftypes.String, "api-key"),
+			endpoint:      tftypes.NewValue(tftypes.String, nil),
+			maxRetries:    tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:  tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent: tftypes.NewValue(tftypes.Number, 2),
+			expectClient:  true,
 		},
 		"unknown config values": {
-			username: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
-			apiKey:   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			username:      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			apiKey:        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			endpoint:      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			maxRetries:    tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+			retryMaxWait:  tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+			maxConcurrent: tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
 			expectedErrSummaries: []string{
 				"Unknown Migadu Username",
 				"Unknown Migadu API Key",
+				"Unknown Migadu Endpoint",
+				"Unknown Migadu Max Retries",
+				"Unknown Migadu Retry Max Wait",
+				"Unknown Migadu Max Concurrent Requests",
 			},
 		},
+		"unknown config values with deferral allowed": {
+			username:        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			apiKey:          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			endpoint:        tftypes.NewValue(tftypes.String, nil),
+			maxRetries:      tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:    tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent:   tftypes.NewValue(tftypes.Number, nil),
+			deferralAllowed: true,
+			expectDeferred:  true,
+		},
+		"unknown endpoint with deferral allowed": {
+			username:        tftypes.NewValue(tftypes.String, "admin@example.com"),
+			apiKey:          tftypes.NewValue(tftypes.String, "api-key"),
+			endpoint:        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			maxRetries:      tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:    tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent:   tftypes.NewValue(tftypes.Number, nil),
+			deferralAllowed: true,
+			expectDeferred:  true,
+		},
+		"known config values with deferral allowed": {
+			username:        tftypes.NewValue(tftypes.String, "admin@example.com"),
+			apiKey:          tftypes.NewValue(tftypes.String, "api-key"),
+			endpoint:        tftypes.NewValue(tftypes.String, nil),
+			maxRetries:      tftypes.NewValue(tftypes.Number, nil),
+			retryMaxWait:    tftypes.NewValue(tftypes.Number, nil),
+			maxConcurrent:   tftypes.NewValue(tftypes.Number, nil),
+			deferralAllowed: true,
+			expectClient:    true,
+		},
 	}
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			t.Setenv("MIGADU_USERNAME", tc.envUsername)
 			t.Setenv("MIGADU_API_KEY", tc.envAPIKey)
+			t.Setenv("MIGADU_ENDPOINT", tc.envEndpoint)
 
 			req := frameworkprovider.ConfigureRequest{
 				Config: newConfigFromSchema(schemaResp.Schema, map[string]tftypes.Value{
-					"username": tc.username,
-					"api_key":  tc.apiKey,
+					"username":                tc.username,
+					"api_key":                 tc.apiKey,
+					"endpoint":                tc.endpoint,
+					"max_retries":             tc.maxRetries,
+					"retry_max_wait":          tc.retryMaxWait,
+					"max_concurrent_requests": tc.maxConcurrent,
 				}),
+				ClientCapabilities: frameworkprovider.ConfigureProviderClientCapabilities{
+					DeferralAllowed: tc.deferralAllowed,
+				},
 			}
 
 			var resp frameworkprovider.ConfigureResponse
 			p.Configure(context.Background(), req, &resp)
 
+			if tc.expectDeferred {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected configure errors: %v", resp.Diagnostics)
+				}
+
+				if resp.Deferred == nil || resp.Deferred.Reason != frameworkprovider.DeferredReasonProviderConfigUnknown {
+					t.Fatalf("expected configure to be deferred for unknown provider config, got %v", resp.Deferred)
+				}
+
+				if resp.ResourceData != nil || resp.DataSourceData != nil || resp.EphemeralResourceData != nil {
+					t.Fatal("expected no provider clients to be set on deferred configure")
+				}
+				return
+			}
+
+			if resp.Deferred != nil {
+				t.Fatalf("unexpected deferred configure: %v", resp.Deferred)
+			}
+
 			if tc.expectClient {
 				if resp.Diagnostics.HasError() {
 					t.Fatalf("unexpected configure errors: %v", resp.Diagnostics)
 				}
 
-				if resp.ResourceData == nil || resp.DataSourceData == nil {
+				if resp.ResourceData == nil || resp.DataSourceData == nil || resp.EphemeralResourceData == nil {
 					t.Fatal("expected provider clients to be set on successful configure")
 				}
 				return
@@ -87,3 +209,76 @@ func TestMigaduProviderConfigure(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderDefersOnUnknownConfig(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["migadu"]()
+	if err != nil {
+		t.Fatalf("failed creating provider server: %s", err)
+	}
+
+	providerSchema := mustProviderSchema(t, &MigaduProvider{}).Schema
+	providerConfig := newConfigFromSchema(providerSchema, map[string]tftypes.Value{
+		"username":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"api_key":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"endpoint":                tftypes.NewValue(tftypes.String, nil),
+		"max_retries":             tftypes.NewValue(tftypes.Number, nil),
+		"retry_max_wait":          tftypes.NewValue(tftypes.Number, nil),
+		"max_concurrent_requests": tftypes.NewValue(tftypes.Number, nil),
+	})
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config:             mustDynamicValue(t, providerConfig.Raw),
+		ClientCapabilities: &tfprotov6.ConfigureProviderClientCapabilities{DeferralAllowed: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(configureResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", configureResp.Diagnostics)
+	}
+
+	aliasState := newResourceConfig(mustResourceSchema(t, NewAliasResource()).Schema, map[string]tftypes.Value{
+		"domain_name": tftypes.NewValue(tftypes.String, testFakeDomain),
+		"local_part":  tftypes.NewValue(tftypes.String, "sales"),
+	})
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:           "migadu_alias",
+		CurrentState:       mustDynamicValue(t, aliasState.Raw),
+		ClientCapabilities: &tfprotov6.ReadResourceClientCapabilities{DeferralAllowed: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if readResp.Deferred == nil || readResp.Deferred.Reason != tfprotov6.DeferredReasonProviderConfigUnknown {
+		t.Errorf("expected resource read to be deferred, got %v", readResp.Deferred)
+	}
+
+	domainsSchema := mustDataSourceSchema(t, NewDomainsDataSource()).Schema
+	domainsType := domainsSchema.Type().TerraformType(ctx).(tftypes.Object)
+	domainsConfig := tftypes.NewValue(domainsType, map[string]tftypes.Value{
+		"domains": tftypes.NewValue(domainsType.AttributeTypes["domains"], nil),
+	})
+	dataResp, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName:           "migadu_domains",
+		Config:             mustDynamicValue(t, domainsConfig),
+		ClientCapabilities: &tfprotov6.ReadDataSourceClientCapabilities{DeferralAllowed: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if dataResp.Deferred == nil || dataResp.Deferred.Reason != tfprotov6.DeferredReasonProviderConfigUnknown {
+		t.Errorf("expected data source read to be deferred, got %v", dataResp.Deferred)
+	}
+}
+
+func mustDynamicValue(t *testing.T, value tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	dynamicValue, err := tfprotov6.NewDynamicValue(value.Type(), value)
+	if err != nil {
+		t.Fatalf("failed creating dynamic value: %s", err)
+	}
+	return &dynamicValue
+}
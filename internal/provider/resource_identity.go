@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// importStateByKey imports a resource addressed by the given string
+// attributes, which are named the same in the resource schema and in its
+// identity schema. The import is either a legacy import ID joining the
+// attribute values with slashes, such as "example.com/alice", or a resource
+// identity from an import block.
+func importStateByKey(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, attributes ...string) {
+	if req.ID == "" {
+		for _, attribute := range attributes {
+			var value types.String
+			resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root(attribute), &value)...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attribute), value)...)
+		}
+		return
+	}
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != len(attributes) {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in format: "+strings.Join(attributes, "/"),
+		)
+		return
+	}
+
+	for i, attribute := range attributes {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attribute), parts[i])...)
+	}
+}
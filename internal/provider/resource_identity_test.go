@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestResourceIdentityImportState(t *testing.T) {
+	testCases := map[string]struct {
+		resource resource.Resource
+		identity map[string]string
+	}{
+		"mailbox": {
+			resource: NewMailboxResource(),
+			identity: map[string]string{"domain_name": "example.com", "local_part": "alice"},
+		},
+		"alias": {
+			resource: NewAliasResource(),
+			identity: map[string]string{"domain_name": "example.com", "local_part": "sales"},
+		},
+		"identity": {
+			resource: NewIdentityResource(),
+			identity: map[string]string{"domain_name": "example.com", "mailbox": "alice", "local_part": "support"},
+		},
+		"rewrite": {
+			resource: NewRewriteResource(),
+			identity: map[string]string{"domain_name": "example.com", "name": "catch-support"},
+		},
+		"domain": {
+			resource: NewDomainResource(),
+			identity: map[string]string{"name": "example.com"},
+		},
+		"domain activation": {
+			resource: NewDomainActivationResource(),
+			identity: map[string]string{"domain_name": "example.com"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, ok := tc.resource.(resource.ResourceWithIdentity)
+			if !ok {
+				t.Fatal("expected resource to implement ResourceWithIdentity")
+			}
+
+			var identityResp resource.IdentitySchemaResponse
+			r.IdentitySchema(context.Background(), resource.IdentitySchemaRequest{}, &identityResp)
+
+			if len(identityResp.IdentitySchema.Attributes) != len(tc.identity) {
+				t.Fatalf("expected %d identity attributes, got %d", len(tc.identity), len(identityResp.IdentitySchema.Attributes))
+			}
+
+			identity := newResourceIdentity(t, r, tc.identity)
+
+			resp := resource.ImportStateResponse{
+				State:    newStateForSchema(mustResourceSchema(t, r).Schema),
+				Identity: identity,
+			}
+
+			r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{Identity: identity}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			for attr, value := range tc.identity {
+				if got := getStateStringAttribute(t, resp.State, attr); got != value {
+					t.Fatalf("expected %s to be %q, got %q", attr, value, got)
+				}
+			}
+		})
+	}
+}
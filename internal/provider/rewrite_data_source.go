@@ -21,11 +21,11 @@ type RewriteDataSource struct {
 }
 
 type RewriteDataSourceModel struct {
-	DomainName    types.String `tfsdk:"domain_name"`
-	Name          types.String `tfsdk:"name"`
-	LocalPartRule types.String `tfsdk:"local_part_rule"`
-	OrderNum      types.Int64  `tfsdk:"order_num"`
-	Destinations  types.List   `tfsdk:"destinations"`
+	DomainName    DomainNameValue `tfsdk:"domain_name"`
+	Name          types.String    `tfsdk:"name"`
+	LocalPartRule types.String    `tfsdk:"local_part_rule"`
+	OrderNum      types.Int64     `tfsdk:"order_num"`
+	Destinations  types.List      `tfsdk:"destinations"`
 }
 
 func (d *RewriteDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,6 +39,7 @@ func (d *RewriteDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"name": schema.StringAttribute{
@@ -56,7 +57,7 @@ func (d *RewriteDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 			"destinations": schema.ListAttribute{
 				MarkdownDescription: "List of destination email addresses.",
 				Computed:            true,
-				ElementType:         types.StringType,
+				ElementType:         EmailAddressType{},
 			},
 		},
 	}
@@ -86,19 +87,19 @@ func (d *RewriteDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	name := data.Name.ValueString()
 
 	rewrite, err := d.client.GetRewrite(ctx, domain, &migadu.Rewrite{Name: name})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read rewrite, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "read rewrite", err)
 		return
 	}
 
 	data.LocalPartRule = types.StringValue(rewrite.LocalPartRule)
 	data.OrderNum = types.Int64Value(int64(rewrite.OrderNum))
 
-	destinations, diags := types.ListValueFrom(ctx, types.StringType, rewrite.Destinations)
+	destinations, diags := types.ListValueFrom(ctx, EmailAddressType{}, normalizeStringSlice(rewrite.Destinations))
 	resp.Diagnostics.Append(diags...)
 	data.Destinations = destinations
 
@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewRewriteDataSourceMetadata(t *testing.T) {
@@ -48,3 +51,27 @@ func TestNewRewriteDataSourceSchemaExpectations(t *testing.T) {
 		t.Fatal("expected order_num to be a computed Int64Attribute")
 	}
 }
+
+func TestAccRewriteDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	ruleName := fmt.Sprintf("tfacc-rewrite-ds-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRewriteConfig(domainName, ruleName) + `
+data "migadu_rewrite" "test" {
+  domain_name = migadu_rewrite.test.domain_name
+  name        = migadu_rewrite.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_rewrite.test", "local_part_rule", "tfacc-catch+"),
+					resource.TestCheckResourceAttr("data.migadu_rewrite.test", "order_num", "50"),
+					resource.TestCheckResourceAttr("data.migadu_rewrite.test", "destinations.0", "admin@"+domainName),
+				),
+			},
+		},
+	})
+}
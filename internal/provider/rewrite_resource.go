@@ -3,12 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/MrLemur/migadu-go"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -17,6 +18,8 @@ import (
 
 var _ resource.Resource = &RewriteResource{}
 var _ resource.ResourceWithImportState = &RewriteResource{}
+var _ resource.ResourceWithIdentity = &RewriteResource{}
+var _ resource.ResourceWithMoveState = &RewriteResource{}
 
 func NewRewriteResource() resource.Resource {
 	return &RewriteResource{}
@@ -27,11 +30,19 @@ type RewriteResource struct {
 }
 
 type RewriteResourceModel struct {
-	DomainName    types.String `tfsdk:"domain_name"`
-	Name          types.String `tfsdk:"name"`
-	LocalPartRule types.String `tfsdk:"local_part_rule"`
-	OrderNum      types.Int64  `tfsdk:"order_num"`
-	Destinations  types.List   `tfsdk:"destinations"`
+	DomainName    DomainNameValue    `tfsdk:"domain_name"`
+	Name          types.String       `tfsdk:"name"`
+	LocalPartRule types.String       `tfsdk:"local_part_rule"`
+	OrderNum      types.Int64        `tfsdk:"order_num"`
+	Destinations  UnorderedListValue `tfsdk:"destinations"`
+	AdoptExisting types.Bool         `tfsdk:"adopt_existing"`
+	Timeouts      timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// RewriteResourceIdentityModel describes the resource identity data model.
+type RewriteResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	Name       types.String `tfsdk:"name"`
 }
 
 func (r *RewriteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,6 +56,7 @@ func (r *RewriteResource) Schema(ctx context.Context, req resource.SchemaRequest
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -71,9 +83,47 @@ func (r *RewriteResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"destinations": schema.ListAttribute{
 				MarkdownDescription: "List of destination email addresses. All destinations must be on the same domain.",
 				Required:            true,
-				ElementType:         types.StringType,
+				ElementType:         EmailAddressType{},
+				CustomType:          NewUnorderedListType(EmailAddressType{}),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over an existing rewrite when creating this resource finds one already there, instead of failing. " +
+					"The existing rewrite is updated to match this configuration. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *RewriteResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the rewrite rule.",
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the rewrite rule.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RewriteResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromMetio("migadu_rewrite", metioRewriteSchema(), []string{"domain_name", "name"}, moveRewriteFromMetio),
 	}
 }
 
@@ -101,6 +151,15 @@ func (r *RewriteResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var destinations []string
 	resp.Diagnostics.Append(data.Destinations.ElementsAs(ctx, &destinations, false)...)
 	if resp.Diagnostics.HasError() {
@@ -111,17 +170,28 @@ func (r *RewriteResource) Create(ctx context.Context, req resource.CreateRequest
 		Name:          data.Name.ValueString(),
 		LocalPartRule: data.LocalPartRule.ValueString(),
 		OrderNum:      int(data.OrderNum.ValueInt64()),
-		Destinations:  destinations,
+		Destinations:  addressesToASCII(destinations),
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
 
-	_, err := r.client.NewRewrite(ctx, domain, rewrite)
+	_, err := createOrAdopt(ctx, data.AdoptExisting.ValueBool(), rewrite.Name+" on "+domain.Name,
+		func() (*migadu.Rewrite, error) { return r.client.NewRewrite(ctx, domain, rewrite) },
+		func() (*migadu.Rewrite, error) { return r.client.GetRewrite(ctx, domain, rewrite) },
+		func() (*migadu.Rewrite, error) { return r.client.UpdateRewrite(ctx, domain, rewrite) },
+	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create rewrite, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "create rewrite", err)
 		return
 	}
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RewriteResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Name:       data.Name,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -132,22 +202,45 @@ func (r *RewriteResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RewriteResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Name:       data.Name,
+	})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	name := data.Name.ValueString()
 
 	rewrite, err := r.client.GetRewrite(ctx, domain, &migadu.Rewrite{Name: name})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read rewrite, got error: %s", err))
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "read rewrite", err)
 		return
 	}
 
 	data.LocalPartRule = types.StringValue(rewrite.LocalPartRule)
 	data.OrderNum = types.Int64Value(int64(rewrite.OrderNum))
 
-	destinations, diags := types.ListValueFrom(ctx, types.StringType, rewrite.Destinations)
+	destinations, diags := NewUnorderedListValueFrom(ctx, EmailAddressType{}, rewrite.Destinations)
 	resp.Diagnostics.Append(diags...)
 	data.Destinations = destinations
 
+	// adopt_existing only affects create; imported resources take the default.
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -158,6 +251,15 @@ func (r *RewriteResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var destinations []string
 	resp.Diagnostics.Append(data.Destinations.ElementsAs(ctx, &destinations, false)...)
 	if resp.Diagnostics.HasError() {
@@ -168,17 +270,24 @@ func (r *RewriteResource) Update(ctx context.Context, req resource.UpdateRequest
 		Name:          data.Name.ValueString(),
 		LocalPartRule: data.LocalPartRule.ValueString(),
 		OrderNum:      int(data.OrderNum.ValueInt64()),
-		Destinations:  destinations,
+		Destinations:  addressesToASCII(destinations),
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
+
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
 
 	_, err := r.client.UpdateRewrite(ctx, domain, rewrite)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update rewrite, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Plan.Schema, "update rewrite", err)
 		return
 	}
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RewriteResourceIdentityModel{
+		DomainName: data.DomainName.StringValue,
+		Name:       data.Name,
+	})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -189,29 +298,30 @@ func (r *RewriteResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	rewrite := &migadu.Rewrite{
 		Name: data.Name.ValueString(),
 	}
 
+	domainLocks.Lock(domain.Name)
+	defer domainLocks.Unlock(domain.Name)
+
 	err := r.client.DeleteRewrite(ctx, domain, rewrite)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete rewrite, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.State.Schema, "delete rewrite", err)
 		return
 	}
 }
 
 func (r *RewriteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Format: domain_name/name
-	parts := strings.Split(req.ID, "/")
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Import ID must be in format: domain_name/name",
-		)
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+	importStateByKey(ctx, req, resp, "domain_name", "name")
 }
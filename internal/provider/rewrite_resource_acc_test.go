@@ -2,46 +2,76 @@ package provider
 
 import (
 	"fmt"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccRewriteResource_basic(t *testing.T) {
-	if os.Getenv("TF_ACC") == "" {
-		t.Skip("set TF_ACC=1 to run acceptance tests")
-	}
+	domainName := testAccSetup(t)
 
 	resourceName := "migadu_rewrite.test"
 	ruleName := fmt.Sprintf("tfacc-rule-%d", time.Now().UnixNano())
-	importID := fmt.Sprintf("%s/%s", os.Getenv("MIGADU_TEST_DOMAIN"), ruleName)
+	importID := fmt.Sprintf("%s/%s", domainName, ruleName)
 
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRewriteConfig(ruleName),
+				Config: testAccRewriteConfig(domainName, ruleName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "domain_name", os.Getenv("MIGADU_TEST_DOMAIN")),
+					resource.TestCheckResourceAttr(resourceName, "domain_name", domainName),
 					resource.TestCheckResourceAttr(resourceName, "name", ruleName),
 					resource.TestCheckResourceAttr(resourceName, "local_part_rule", "tfacc-catch+"),
 					resource.TestCheckResourceAttr(resourceName, "order_num", "50"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateId:     importID,
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        importID,
+				ImportStateVerifyIdentifierAttribute: "name",
 			},
 		},
 	})
 }
 
-func testAccRewriteConfig(ruleName string) string {
+func TestAccRewriteResource_identity(t *testing.T) {
+	domainName := testAccSetup(t)
+
+	resourceName := "migadu_rewrite.test"
+	ruleName := fmt.Sprintf("tfacc-rule-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRewriteConfig(domainName, ruleName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resourceName, map[string]knownvalue.Check{
+						"domain_name": knownvalue.StringExact(domainName),
+						"name":        knownvalue.StringExact(ruleName),
+					}),
+				},
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func testAccRewriteConfig(domainName, ruleName string) string {
 	return fmt.Sprintf(`
 resource "migadu_rewrite" "test" {
   domain_name     = "%s"
@@ -50,5 +80,5 @@ resource "migadu_rewrite" "test" {
   order_num       = 50
   destinations    = ["admin@%s"]
 }
-`, os.Getenv("MIGADU_TEST_DOMAIN"), ruleName, os.Getenv("MIGADU_TEST_DOMAIN"))
+`, domainName, ruleName, domainName)
 }
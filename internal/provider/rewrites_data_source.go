@@ -22,8 +22,8 @@ type RewritesDataSource struct {
 }
 
 type RewritesDataSourceModel struct {
-	DomainName types.String `tfsdk:"domain_name"`
-	Rewrites   types.List   `tfsdk:"rewrites"`
+	DomainName DomainNameValue `tfsdk:"domain_name"`
+	Rewrites   types.List      `tfsdk:"rewrites"`
 }
 
 type RewriteListItemModel struct {
@@ -43,6 +43,7 @@ func (d *RewritesDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				MarkdownDescription: "The domain name.",
+				CustomType:          DomainNameType{},
 				Required:            true,
 			},
 			"rewrites": schema.ListNestedAttribute{
@@ -64,7 +65,7 @@ func (d *RewritesDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 						},
 						"destinations": schema.ListAttribute{
 							MarkdownDescription: "List of destination email addresses.",
-							ElementType:         types.StringType,
+							ElementType:         EmailAddressType{},
 							Computed:            true,
 						},
 					},
@@ -98,16 +99,16 @@ func (d *RewritesDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	domain := &migadu.Domain{Name: data.DomainName.ValueString()}
+	domain := &migadu.Domain{Name: data.DomainName.ValueASCII()}
 	rewrites, err := d.client.ListRewrites(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list rewrites, got error: %s", err))
+		addClientError(ctx, &resp.Diagnostics, req.Config.Schema, "list rewrites", err)
 		return
 	}
 
 	items := make([]RewriteListItemModel, 0, len(rewrites))
 	for _, rewrite := range rewrites {
-		destinations, diags := types.ListValueFrom(ctx, types.StringType, rewrite.Destinations)
+		destinations, diags := types.ListValueFrom(ctx, EmailAddressType{}, normalizeStringSlice(rewrite.Destinations))
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -126,7 +127,7 @@ func (d *RewritesDataSource) Read(ctx context.Context, req datasource.ReadReques
 			"name":            types.StringType,
 			"local_part_rule": types.StringType,
 			"order_num":       types.Int64Type,
-			"destinations":    types.ListType{ElemType: types.StringType},
+			"destinations":    types.ListType{ElemType: EmailAddressType{}},
 		},
 	}, items)
 	resp.Diagnostics.Append(diags...)
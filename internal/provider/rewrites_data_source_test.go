@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestNewRewritesDataSourceMetadata(t *testing.T) {
@@ -51,3 +54,27 @@ func TestNewRewritesDataSourceSchemaExpectations(t *testing.T) {
 		t.Fatal("expected rewrites.order_num to be a computed Int64Attribute")
 	}
 }
+
+func TestAccRewritesDataSource_basic(t *testing.T) {
+	domainName := testAccSetup(t)
+	ruleName := fmt.Sprintf("tfacc-rewrite-ds-%d", time.Now().UnixNano())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRewriteConfig(domainName, ruleName) + `
+data "migadu_rewrites" "test" {
+  domain_name = migadu_rewrite.test.domain_name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.migadu_rewrites.test", "rewrites.*", map[string]string{
+						"name":            ruleName,
+						"local_part_rule": "tfacc-catch+",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// TestResourceSchemaVersions checks that every resource can upgrade state
+// from each earlier version of its schema. Attributes can be added without a
+// new version, as state that lacks them reads them as null. A change that
+// Terraform can't read as is, such as an attribute changing type, bumps the
+// version and adds an upgrader with the frozen schema of the previous version
+// as its PriorSchema.
+func TestResourceSchemaVersions(t *testing.T) {
+	ctx := context.Background()
+
+	for _, newResource := range (&MigaduProvider{}).Resources(ctx) {
+		r := newResource()
+		schema := mustResourceSchema(t, r).Schema
+
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "migadu"}, &metadata)
+
+		t.Run(metadata.TypeName, func(t *testing.T) {
+			var upgraders map[int64]resource.StateUpgrader
+			if upgrader, ok := r.(resource.ResourceWithUpgradeState); ok {
+				upgraders = upgrader.UpgradeState(ctx)
+			}
+
+			for version := int64(0); version < schema.Version; version++ {
+				upgrader, ok := upgraders[version]
+				if !ok {
+					t.Errorf("expected a state upgrader from version %d", version)
+					continue
+				}
+				if upgrader.PriorSchema == nil {
+					t.Errorf("expected the state upgrader from version %d to have a prior schema", version)
+				}
+			}
+
+			for version := range upgraders {
+				if version >= schema.Version {
+					t.Errorf("unexpected state upgrader from version %d, the schema is at version %d", version, schema.Version)
+				}
+			}
+		})
+	}
+}
+
+// TestResourceUpgradeStateFromV0 feeds state written by earlier releases
+// through UpgradeResourceState. Attributes added since are read as null, to
+// be filled in by Read, and attributes that were removed are ignored.
+func TestResourceUpgradeStateFromV0(t *testing.T) {
+	testCases := map[string]struct {
+		resource resource.Resource
+		rawState string
+		expected map[string]string
+	}{
+		"mailbox": {
+			resource: NewMailboxResource(),
+			rawState: `{
+				"domain_name": "example.com",
+				"local_part": "alice",
+				"name": "Alice",
+				"password_method": "password",
+				"password": "secret",
+				"password_recovery_email": "",
+				"may_send": true,
+				"may_receive": true,
+				"may_access_imap": true,
+				"may_access_pop3": true,
+				"may_access_managesieve": true,
+				"spam_action": "folder",
+				"spam_aggressiveness": "default",
+				"footer_active": false,
+				"footer_plain_body": "",
+				"footer_html_body": "",
+				"sender_allowlist": ["bob@example.org"],
+				"sender_denylist": [],
+				"recipient_denylist": [],
+				"address": "alice@example.com",
+				"is_internal": false,
+				"storage_usage": 1024,
+				"changed_at": "2024-01-01T00:00:00Z",
+				"last_login_at": ""
+			}`,
+			expected: map[string]string{
+				"address":             `"alice@example.com"`,
+				"password":            `"secret"`,
+				"sender_allowlist":    `["bob@example.org"]`,
+				"storage_usage":       `1024`,
+				"adopt_existing":      `<null>`,
+				"password_wo_version": `<null>`,
+				"timeouts":            `<null>`,
+			},
+		},
+		"alias": {
+			resource: NewAliasResource(),
+			rawState: `{
+				"domain_name": "example.com",
+				"local_part": "sales",
+				"destinations": ["alice@example.com", "bob@example.com"],
+				"address": "sales@example.com",
+				"is_internal": false
+			}`,
+			expected: map[string]string{
+				"local_part":     `"sales"`,
+				"destinations":   `["alice@example.com","bob@example.com"]`,
+				"adopt_existing": `<null>`,
+			},
+		},
+		"identity": {
+			resource: NewIdentityResource(),
+			rawState: `{
+				"domain_name": "example.com",
+				"mailbox": "alice",
+				"local_part": "support",
+				"name": "Support",
+				"password": null,
+				"may_send": true,
+				"may_receive": true,
+				"may_access_imap": false,
+				"may_access_pop3": false,
+				"may_access_managesieve": false,
+				"address": "support@example.com"
+			}`,
+			expected: map[string]string{
+				"mailbox":        `"alice"`,
+				"address":        `"support@example.com"`,
+				"adopt_existing": `<null>`,
+			},
+		},
+		"rewrite": {
+			resource: NewRewriteResource(),
+			rawState: `{
+				"domain_name": "example.com",
+				"name": "catch-support",
+				"local_part_rule": "support-*",
+				"order_num": 1,
+				"destinations": ["alice@example.com"]
+			}`,
+			expected: map[string]string{
+				"local_part_rule": `"support-*"`,
+				"order_num":       `1`,
+				"adopt_existing":  `<null>`,
+			},
+		},
+		"domain": {
+			resource: NewDomainResource(),
+			rawState: `{
+				"name": "bücher.example",
+				"state": "active",
+				"description": "",
+				"tags": [],
+				"spam_aggressiveness": "default",
+				"greylisting_enabled": false,
+				"mx_proxy_enabled": false,
+				"hosted_dns": false,
+				"sender_allowlist": [],
+				"sender_denylist": [],
+				"recipient_denylist": [],
+				"catchall_destinations": ["admin@bücher.example"]
+			}`,
+			expected: map[string]string{
+				"state":        `"active"`,
+				"name_ascii":   `<null>`,
+				"name_unicode": `<null>`,
+			},
+		},
+		"domain activation": {
+			resource: NewDomainActivationResource(),
+			rawState: `{
+				"domain_name": "example.com",
+				"state": "active",
+				"removed_attribute": "ignored"
+			}`,
+			expected: map[string]string{
+				"state":        `"active"`,
+				"wait_for_dns": `<null>`,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			schema := mustResourceSchema(t, tc.resource).Schema
+
+			var metadata resource.MetadataResponse
+			tc.resource.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "migadu"}, &metadata)
+
+			server, err := testAccProtoV6ProviderFactories["migadu"]()
+			if err != nil {
+				t.Fatalf("failed creating provider server: %s", err)
+			}
+
+			resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: metadata.TypeName,
+				Version:  0,
+				RawState: &tfprotov6.RawState{JSON: []byte(tc.rawState)},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, diagnostic := range resp.Diagnostics {
+				if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+			}
+
+			value, err := resp.UpgradedState.Unmarshal(schema.Type().TerraformType(ctx))
+			if err != nil {
+				t.Fatalf("failed reading upgraded state: %s", err)
+			}
+			state := tfsdk.State{Schema: schema, Raw: value}
+
+			for attribute, expected := range tc.expected {
+				var value attr.Value
+				diags := state.GetAttribute(ctx, path.Root(attribute), &value)
+				if diags.HasError() {
+					t.Fatalf("failed reading %s: %v", attribute, diags)
+				}
+				if got := value.String(); got != expected {
+					t.Errorf("expected %s to be %s, got %s", attribute, expected, got)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -49,6 +51,26 @@ func newConfigFromSchema(schema providerschema.Schema, values map[string]tftypes
 	}
 }
 
+// newResourceConfig returns a resource configuration with the given attribute
+// values. Attributes and blocks that are not given are null.
+func newResourceConfig(schema resourceschema.Schema, values map[string]tftypes.Value) tfsdk.Config {
+	objectType := schema.Type().TerraformType(context.Background()).(tftypes.Object)
+
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+			continue
+		}
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	return tfsdk.Config{
+		Schema: schema,
+		Raw:    tftypes.NewValue(objectType, attrs),
+	}
+}
+
 func newPlanForSchema(schema resourceschema.Schema) tfsdk.Plan {
 	objectType := schema.Type().TerraformType(context.Background())
 	return tfsdk.Plan{
@@ -57,6 +79,26 @@ func newPlanForSchema(schema resourceschema.Schema) tfsdk.Plan {
 	}
 }
 
+// nullTimeouts returns an unset timeouts block value matching the schema, for
+// populating resource models in tests.
+func nullTimeouts(t *testing.T, schema resourceschema.Schema) timeouts.Value {
+	t.Helper()
+
+	timeoutsType, ok := schema.Blocks["timeouts"].Type().(timeouts.Type)
+	if !ok {
+		t.Fatal("expected schema to have a timeouts block")
+	}
+	return timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)}
+}
+
+// configFromPlan returns a configuration holding the same values as plan.
+func configFromPlan(plan tfsdk.Plan) tfsdk.Config {
+	return tfsdk.Config{
+		Schema: plan.Schema,
+		Raw:    plan.Raw.Copy(),
+	}
+}
+
 func newStateForSchema(schema resourceschema.Schema) tfsdk.State {
 	objectType := schema.Type().TerraformType(context.Background())
 	return tfsdk.State{
@@ -65,6 +107,31 @@ func newStateForSchema(schema resourceschema.Schema) tfsdk.State {
 	}
 }
 
+// newResourceIdentity returns an identity for r holding the given attribute
+// values, or a null identity when values is nil.
+func newResourceIdentity(t *testing.T, r resource.Resource, values map[string]string) *tfsdk.ResourceIdentity {
+	t.Helper()
+
+	identityResource, ok := r.(resource.ResourceWithIdentity)
+	if !ok {
+		t.Fatal("expected resource to implement ResourceWithIdentity")
+	}
+
+	var resp resource.IdentitySchemaResponse
+	identityResource.IdentitySchema(context.Background(), resource.IdentitySchemaRequest{}, &resp)
+	objectType := resp.IdentitySchema.Type().TerraformType(context.Background())
+
+	if values == nil {
+		return &tfsdk.ResourceIdentity{Schema: resp.IdentitySchema, Raw: tftypes.NewValue(objectType, nil)}
+	}
+
+	attrs := make(map[string]tftypes.Value, len(values))
+	for name, value := range values {
+		attrs[name] = tftypes.NewValue(tftypes.String, value)
+	}
+	return &tfsdk.ResourceIdentity{Schema: resp.IdentitySchema, Raw: tftypes.NewValue(objectType, attrs)}
+}
+
 func assertHasDiagnosticSummary(t *testing.T, diags diag.Diagnostics, summary string) {
 	t.Helper()
 
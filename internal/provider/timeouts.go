@@ -0,0 +1,12 @@
+package provider
+
+import "time"
+
+// Default deadlines for resource operations. Each resource accepts a timeouts
+// block to override them.
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
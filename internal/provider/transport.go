@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries   = 4
+	defaultRetryMaxWait = 30 * time.Second
+	retryMinWait        = 1 * time.Second
+)
+
+// retryTransport retries Migadu API requests that failed with a rate limit,
+// a transient server error or a network error.
+//
+// Requests with idempotent methods are retried on any of these failures.
+// Other requests (POST) are only retried on 429 Too Many Requests, which
+// Migadu returns before processing the request, so that a create is never
+// sent twice.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+	minWait    time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		base:       base,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+		minWait:    retryMinWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if attempt >= t.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > t.maxWait {
+					// The server asked us to back off for longer than we are
+					// allowed to wait, so surface the response instead.
+					return resp, nil
+				}
+				wait = retryAfter
+			}
+
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.Debug(ctx, "Retrying Migadu API request", map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.Redacted(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"status":  statusOf(resp),
+			"error":   errorString(err),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return req.Context().Err() == nil && isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// backoff returns a jittered exponential delay for the given retry attempt,
+// between half and all of minWait*2^attempt, capped at maxWait.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.maxWait
+	if attempt < 32 {
+		if d := t.minWait << attempt; d > 0 && d < t.maxWait {
+			wait = d
+		}
+	}
+
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter parses a Retry-After header given either as a number of
+// seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func statusOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// limitTransport caps the number of Migadu API requests in flight. A slot is
+// held until the response body is closed, and is not held while a retried
+// request waits to be sent again.
+type limitTransport struct {
+	base  http.RoundTripper
+	slots chan struct{}
+}
+
+func newLimitTransport(base http.RoundTripper, maxConcurrent int) *limitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &limitTransport{
+		base:  base,
+		slots: make(chan struct{}, maxConcurrent),
+	}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	release := sync.OnceFunc(func() { <-t.slots })
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingBody calls release once the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer responds with the given status codes in order, then 200 OK for
+// every request after that. It records the request bodies it receives.
+type flakyServer struct {
+	*httptest.Server
+
+	requests atomic.Int32
+	bodies   []string
+}
+
+func newFlakyServer(t *testing.T, header http.Header, statuses ...int) *flakyServer {
+	t.Helper()
+
+	s := &flakyServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.bodies = append(s.bodies, string(body))
+
+		n := int(s.requests.Add(1))
+		if n <= len(statuses) {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(statuses[n-1])
+			_, _ = w.Write([]byte(`{"error":"injected failure"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newTestRetryClient(maxRetries int, maxWait time.Duration) *http.Client {
+	transport := newRetryTransport(http.DefaultTransport, maxRetries, maxWait)
+	transport.minWait = time.Millisecond
+	return &http.Client{Transport: transport}
+}
+
+func doRequest(t *testing.T, client *http.Client, method, url, body string) *http.Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, reader)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %s", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected request error: %s", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+func TestRetryTransportRetriesTransientFailures(t *testing.T) {
+	testCases := map[string]struct {
+		method           string
+		statuses         []int
+		expectedStatus   int
+		expectedRequests int32
+	}{
+		"get retried on server errors": {
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusInternalServerError},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 4,
+		},
+		"delete retried on rate limit": {
+			method:           http.MethodDelete,
+			statuses:         []int{http.StatusTooManyRequests},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		"post retried on rate limit": {
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 3,
+		},
+		"post not retried on server error": {
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusBadGateway},
+			expectedStatus:   http.StatusBadGateway,
+			expectedRequests: 1,
+		},
+		"client errors not retried": {
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusNotFound},
+			expectedStatus:   http.StatusNotFound,
+			expectedRequests: 1,
+		},
+		"gives up after max retries": {
+			method:           http.MethodGet,
+			statuses:         []int{503, 503, 503, 503, 503, 503},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedRequests: 4,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := newFlakyServer(t, nil, tc.statuses...)
+			client := newTestRetryClient(3, time.Second)
+
+			resp := doRequest(t, client, tc.method, server.URL, "")
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+			if got := server.requests.Load(); got != tc.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", tc.expectedRequests, got)
+			}
+		})
+	}
+}
+
+func TestRetryTransportReplaysRequestBody(t *testing.T) {
+	server := newFlakyServer(t, nil, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	client := newTestRetryClient(3, time.Second)
+
+	resp := doRequest(t, client, http.MethodPut, server.URL, `{"name":"Alice"}`)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	for i, body := range server.bodies {
+		if body != `{"name":"Alice"}` {
+			t.Fatalf("request %d: expected replayed body, got %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	server := newFlakyServer(t, http.Header{"Retry-After": []string{"1"}}, http.StatusTooManyRequests)
+	client := newTestRetryClient(3, 5*time.Second)
+
+	start := time.Now()
+	resp := doRequest(t, client, http.MethodGet, server.URL, "")
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait at least 1s for Retry-After, waited %s", elapsed)
+	}
+}
+
+func TestRetryTransportRetryAfterBeyondMaxWait(t *testing.T) {
+	server := newFlakyServer(t, http.Header{"Retry-After": []string{"120"}}, http.StatusTooManyRequests)
+	client := newTestRetryClient(3, 5*time.Second)
+
+	resp := doRequest(t, client, http.MethodGet, server.URL, "")
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", resp.StatusCode)
+	}
+	if got := server.requests.Load(); got != 1 {
+		t.Fatalf("expected 1 request, got %d", got)
+	}
+}
+
+func TestRetryTransportStopsOnContextCancel(t *testing.T) {
+	server := newFlakyServer(t, http.Header{"Retry-After": []string{"5"}}, http.StatusTooManyRequests)
+	client := newTestRetryClient(3, 10*time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %s", err)
+	}
+
+	start := time.Now()
+	_, err = client.Do(req)
+	if err == nil {
+		t.Fatal("expected context error, got none")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected cancellation to interrupt the wait, waited %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"empty":       {value: "", ok: false},
+		"seconds":     {value: "7", expected: 7 * time.Second, ok: true},
+		"negative":    {value: "-1", ok: false},
+		"http date":   {value: "Mon, 01 Jan 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		"past date":   {value: "Mon, 01 Jan 2024 11:00:00 GMT", expected: 0, ok: true},
+		"unparseable": {value: "soon", ok: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tc.value, now)
+			if ok != tc.ok || got != tc.expected {
+				t.Fatalf("expected (%s, %t), got (%s, %t)", tc.expected, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestRetryTransportBackoffBounds(t *testing.T) {
+	transport := newRetryTransport(nil, 10, 8*time.Second)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		ceiling := min(retryMinWait<<attempt, 8*time.Second)
+		for i := 0; i < 20; i++ {
+			wait := transport.backoff(attempt)
+			if wait < ceiling/2 || wait > ceiling {
+				t.Fatalf("attempt %d: backoff %s outside [%s, %s]", attempt, wait, ceiling/2, ceiling)
+			}
+		}
+	}
+}
+
+func TestLimitTransportCapsConcurrentRequests(t *testing.T) {
+	const limit = 2
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			current := peak.Load()
+			if n <= current || peak.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, limit)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected request error: %s", err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > limit {
+		t.Fatalf("expected at most %d concurrent requests, got %d", limit, got)
+	}
+	if got := len(client.Transport.(*limitTransport).slots); got != 0 {
+		t.Fatalf("expected all slots to be released, %d still held", got)
+	}
+}
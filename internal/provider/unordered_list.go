@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the custom list type and value satisfy framework interfaces.
+var _ basetypes.ListTypable = UnorderedListType{}
+var _ basetypes.ListValuableWithSemanticEquals = UnorderedListValue{}
+
+// UnorderedListType is a list type whose values compare equal when they hold
+// the same elements, regardless of order or duplicates. Migadu does not keep
+// the order of address lists, so without it a Read that gets the entries back
+// in a different order would show a diff.
+type UnorderedListType struct {
+	basetypes.ListType
+}
+
+func NewUnorderedListType(elemType attr.Type) UnorderedListType {
+	return UnorderedListType{ListType: basetypes.ListType{ElemType: elemType}}
+}
+
+func (t UnorderedListType) Equal(o attr.Type) bool {
+	other, ok := o.(UnorderedListType)
+	if !ok {
+		return false
+	}
+
+	return t.ListType.Equal(other.ListType)
+}
+
+func (t UnorderedListType) String() string {
+	return fmt.Sprintf("UnorderedListType[%s]", t.ElementType())
+}
+
+func (t UnorderedListType) ValueFromList(ctx context.Context, in basetypes.ListValue) (basetypes.ListValuable, diag.Diagnostics) {
+	return UnorderedListValue{ListValue: in}, nil
+}
+
+func (t UnorderedListType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.ListType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	listValue, ok := attrValue.(basetypes.ListValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	listValuable, diags := t.ValueFromList(ctx, listValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting ListValue to ListValuable: %v", diags)
+	}
+
+	return listValuable, nil
+}
+
+func (t UnorderedListType) ValueType(ctx context.Context) attr.Value {
+	return UnorderedListValue{ListValue: basetypes.NewListNull(t.ElementType())}
+}
+
+// UnorderedListValue is a value of UnorderedListType.
+type UnorderedListValue struct {
+	basetypes.ListValue
+}
+
+func NewUnorderedListNull(elemType attr.Type) UnorderedListValue {
+	return UnorderedListValue{ListValue: basetypes.NewListNull(elemType)}
+}
+
+// NewUnorderedListValueFrom returns a list of the given strings as elements of
+// elemType, which must be a string type. A nil slice becomes an empty list.
+func NewUnorderedListValueFrom(ctx context.Context, elemType attr.Type, values []string) (UnorderedListValue, diag.Diagnostics) {
+	listValue, diags := types.ListValueFrom(ctx, elemType, normalizeStringSlice(values))
+	return UnorderedListValue{ListValue: listValue}, diags
+}
+
+func (v UnorderedListValue) Type(ctx context.Context) attr.Type {
+	return NewUnorderedListType(v.ElementType(ctx))
+}
+
+func (v UnorderedListValue) Equal(o attr.Value) bool {
+	other, ok := o.(UnorderedListValue)
+	if !ok {
+		return false
+	}
+
+	return v.ListValue.Equal(other.ListValue)
+}
+
+// ListSemanticEquals reports whether both lists hold the same set of
+// elements. Null and unknown lists are only equal to themselves.
+func (v UnorderedListValue) ListSemanticEquals(ctx context.Context, newValuable basetypes.ListValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(UnorderedListValue)
+	if !ok {
+		return false, semanticEqualityError(v, newValuable)
+	}
+
+	if v.IsNull() || v.IsUnknown() || newValue.IsNull() || newValue.IsUnknown() {
+		return v.ListValue.Equal(newValue.ListValue), diags
+	}
+
+	return containsAll(ctx, v.Elements(), newValue.Elements()) && containsAll(ctx, newValue.Elements(), v.Elements()), diags
+}
+
+func containsAll(ctx context.Context, haystack, needles []attr.Value) bool {
+	for _, needle := range needles {
+		if !slices.ContainsFunc(haystack, func(v attr.Value) bool { return elementSemanticEquals(ctx, needle, v) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// elementSemanticEquals reports whether two list elements are equal, using
+// the semantic equality of their string type if it has one.
+func elementSemanticEquals(ctx context.Context, a, b attr.Value) bool {
+	if a.Equal(b) {
+		return true
+	}
+
+	aValuable, ok := a.(basetypes.StringValuableWithSemanticEquals)
+	if !ok {
+		return false
+	}
+	bValuable, ok := b.(basetypes.StringValuable)
+	if !ok {
+		return false
+	}
+
+	equal, diags := aValuable.StringSemanticEquals(ctx, bValuable)
+	return equal && !diags.HasError()
+}
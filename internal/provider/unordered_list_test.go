@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUnorderedListSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	list := func(values ...string) UnorderedListValue {
+		elements := make([]attr.Value, 0, len(values))
+		for _, v := range values {
+			elements = append(elements, types.StringValue(v))
+		}
+		return UnorderedListValue{ListValue: types.ListValueMust(types.StringType, elements)}
+	}
+
+	testCases := map[string]struct {
+		prior    UnorderedListValue
+		new      UnorderedListValue
+		expected bool
+	}{
+		"same order":         {prior: list("a", "b"), new: list("a", "b"), expected: true},
+		"reordered":          {prior: list("b", "a"), new: list("a", "b"), expected: true},
+		"duplicates dropped": {prior: list("a", "b", "a"), new: list("a", "b"), expected: true},
+		"both empty":         {prior: list(), new: list(), expected: true},
+		"element missing":    {prior: list("a", "b"), new: list("a"), expected: false},
+		"element added":      {prior: list("a"), new: list("a", "b"), expected: false},
+		"element changed":    {prior: list("a", "b"), new: list("a", "c"), expected: false},
+		"null and empty":     {prior: NewUnorderedListNull(types.StringType), new: list(), expected: false},
+		"both null":          {prior: NewUnorderedListNull(types.StringType), new: NewUnorderedListNull(types.StringType), expected: true},
+		"unknown": {
+			prior:    UnorderedListValue{ListValue: types.ListUnknown(types.StringType)},
+			new:      list("a"),
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := tc.prior.ListSemanticEquals(ctx, tc.new)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnorderedListSemanticEqualsEmailAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	list := func(values ...string) UnorderedListValue {
+		elements := make([]attr.Value, 0, len(values))
+		for _, v := range values {
+			elements = append(elements, NewEmailAddressValue(v))
+		}
+		return UnorderedListValue{ListValue: types.ListValueMust(EmailAddressType{}, elements)}
+	}
+
+	testCases := map[string]struct {
+		prior    UnorderedListValue
+		new      UnorderedListValue
+		expected bool
+	}{
+		"case differs":            {prior: list("Amy@Example.com"), new: list("amy@example.com"), expected: true},
+		"case differs, reordered": {prior: list("Zed@example.com", "amy@example.com"), new: list("amy@example.com", "zed@example.com"), expected: true},
+		"address changed":         {prior: list("amy@example.com"), new: list("bob@example.com"), expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := tc.prior.ListSemanticEquals(ctx, tc.new)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnorderedListTypeValueFromTerraform(t *testing.T) {
+	ctx := context.Background()
+	listType := NewUnorderedListType(types.StringType)
+
+	value, err := listType.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "a@example.com"),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	listValue, ok := value.(UnorderedListValue)
+	if !ok {
+		t.Fatalf("expected UnorderedListValue, got %T", value)
+	}
+	if !listValue.Type(ctx).Equal(listType) {
+		t.Fatalf("expected value type %s, got %s", listType, listValue.Type(ctx))
+	}
+	if len(listValue.Elements()) != 1 {
+		t.Fatalf("expected 1 element, got %d", len(listValue.Elements()))
+	}
+}
+
+func TestNormalizeStringSlice(t *testing.T) {
+	testCases := map[string]struct {
+		values   []string
+		expected []string
+	}{
+		"nil":        {values: nil, expected: []string{}},
+		"sorted":     {values: []string{"a", "b"}, expected: []string{"a", "b"}},
+		"reordered":  {values: []string{"b", "a"}, expected: []string{"a", "b"}},
+		"duplicates": {values: []string{"b", "a", "b"}, expected: []string{"a", "b"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := normalizeStringSlice(tc.values); !slices.Equal(got, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}